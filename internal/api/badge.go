@@ -0,0 +1,135 @@
+package api
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"goodreads-scraper/internal/scraper"
+)
+
+// Badge colours, matching the shields.io palette
+const (
+	badgeBlue        = "#007ec6"
+	badgeBrightGreen = "#4c1"
+	badgeGreen       = "#97ca00"
+	badgeYellowGreen = "#a4a61d"
+	badgeOrange      = "#fe7d37"
+	badgeGrey        = "#9f9f9f"
+)
+
+// badgeMaxAge is how long image proxies such as GitHub's camo may cache a badge
+const badgeMaxAge = 3600
+
+// badgeTemplate is a flat shields.io-style badge; widths are in pixels
+const badgeTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">` +
+	`<title>%[4]s: %[5]s</title>` +
+	`<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` +
+	`<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>` +
+	`<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>` +
+	`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` +
+	`<text x="%[7]d" y="15" fill="#010101" fill-opacity=".3">%[4]s</text><text x="%[7]d" y="14">%[4]s</text>` +
+	`<text x="%[8]d" y="15" fill="#010101" fill-opacity=".3">%[5]s</text><text x="%[8]d" y="14">%[5]s</text>` +
+	`</g></svg>`
+
+// renderBadge renders a two-part badge with a grey label and coloured message
+func renderBadge(label, message, color string) string {
+	labelWidth := badgeTextWidth(label)
+	messageWidth := badgeTextWidth(message)
+
+	return fmt.Sprintf(badgeTemplate,
+		labelWidth+messageWidth,
+		labelWidth,
+		messageWidth,
+		html.EscapeString(label),
+		html.EscapeString(message),
+		color,
+		labelWidth/2,
+		labelWidth+messageWidth/2,
+	)
+}
+
+// badgeTextWidth approximates the rendered width of 11px Verdana plus padding
+func badgeTextWidth(text string) int {
+	return utf8.RuneCountInString(text)*7 + 10
+}
+
+// ratingColor grades an average rating out of five
+func ratingColor(rating float64) string {
+	switch {
+	case rating >= 4:
+		return badgeBrightGreen
+	case rating >= 3.5:
+		return badgeGreen
+	case rating >= 3:
+		return badgeYellowGreen
+	case rating > 0:
+		return badgeOrange
+	default:
+		return badgeGrey
+	}
+}
+
+// progressColor grades progress towards a goal
+func progressColor(done, goal int) string {
+	switch percent := done * 100 / goal; {
+	case percent >= 100:
+		return badgeBrightGreen
+	case percent >= 75:
+		return badgeGreen
+	case percent >= 50:
+		return badgeYellowGreen
+	default:
+		return badgeOrange
+	}
+}
+
+// writeBadge sends an SVG badge that image proxies may cache
+func writeBadge(c *gin.Context, label, message, color string) {
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", badgeMaxAge))
+	c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", []byte(renderBadge(label, message, color)))
+}
+
+// badge serves a badge rendered from the user's cached reading stats. Scrape
+// failures still render (as a grey badge) since READMEs can't show error bodies
+func (h *Handler) badge(label string, render func(c *gin.Context, stats *scraper.ReadingStats) (string, string)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stats, err := h.loadStats(c.Param("username"))
+		if err != nil {
+			c.Header("Cache-Control", "no-cache")
+			c.Data(http.StatusOK, "image/svg+xml; charset=utf-8", []byte(renderBadge(label, "unavailable", badgeGrey)))
+			return
+		}
+
+		setDataAge(c, stats.LastUpdated)
+		message, color := render(c, stats)
+		writeBadge(c, label, message, color)
+	}
+}
+
+// booksReadBadge shows the total number of books read
+func booksReadBadge(c *gin.Context, stats *scraper.ReadingStats) (string, string) {
+	return strconv.Itoa(stats.TotalBooks), badgeBlue
+}
+
+// avgRatingBadge shows the average rating given, coloured by grade
+func avgRatingBadge(c *gin.Context, stats *scraper.ReadingStats) (string, string) {
+	if stats.AverageRating == 0 {
+		return "n/a", badgeGrey
+	}
+	return fmt.Sprintf("%.2f/5", stats.AverageRating), ratingColor(stats.AverageRating)
+}
+
+// challengeBadge shows books read this year, against the ?goal= query
+// parameter when given since the reading challenge goal isn't scraped
+func challengeBadge(c *gin.Context, stats *scraper.ReadingStats) (string, string) {
+	goal, err := strconv.Atoi(c.Query("goal"))
+	if err != nil || goal <= 0 {
+		return strconv.Itoa(stats.BooksThisYear), badgeBlue
+	}
+	return fmt.Sprintf("%d/%d", stats.BooksThisYear, goal), progressColor(stats.BooksThisYear, goal)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"goodreads-scraper/internal/consent"
+	"goodreads-scraper/internal/scraper"
+)
+
+// optOutPathPrefix marks routes that must keep working for opted-out users
+const optOutPathPrefix = "/api/v1/opt-out/"
+
+// enforceOptOut refuses requests about users who opted out of scraping
+func (h *Handler) enforceOptOut(c *gin.Context) {
+	username := c.Param("username")
+	if username == "" || strings.HasPrefix(c.FullPath(), optOutPathPrefix) {
+		c.Next()
+		return
+	}
+
+	if h.consent.IsOptedOut(username) {
+		c.JSON(http.StatusForbidden, scraper.ErrorResponse{
+			Error:   "user_opted_out",
+			Message: "This Goodreads user has opted out of being scraped by this service",
+		})
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// requestOptOut starts an opt-out and returns the code to place in the profile bio
+func (h *Handler) requestOptOut(c *gin.Context) {
+	username := c.Param("username")
+
+	code, expiresAt, err := h.consent.Begin(username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, scraper.ErrorResponse{
+			Error:   "opt_out_failed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"username":     h.ids.Encode(username),
+		"code":         code,
+		"expires_at":   expiresAt,
+		"instructions": "Add the code to the About Me section of your Goodreads profile, then POST to the verify endpoint. You can remove it once verified.",
+		"verify_url":   optOutPathPrefix + h.ids.Encode(username) + "/verify",
+	})
+}
+
+// verifyOptOut checks the profile bio for the code and records the opt-out
+func (h *Handler) verifyOptOut(c *gin.Context) {
+	username := c.Param("username")
+
+	bio, err := h.scraper.GetProfileBio(username)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, scraper.ErrorResponse{
+			Error:   "scraping_failed",
+			Message: "Failed to read profile: " + err.Error(),
+		})
+		return
+	}
+
+	verified, err := h.consent.Verify(username, bio)
+	if errors.Is(err, consent.ErrNoPendingRequest) {
+		c.JSON(http.StatusNotFound, scraper.ErrorResponse{
+			Error:   "no_pending_opt_out",
+			Message: "No pending opt-out request; request a new code first",
+		})
+		return
+	}
+	if err != nil {
+		// The opt-out is active in memory even if persisting it failed
+		log.Printf("Warning: failed to persist opt-out for %s: %v", username, err)
+	}
+
+	if !verified {
+		c.JSON(http.StatusConflict, scraper.ErrorResponse{
+			Error:   "code_not_found",
+			Message: "The verification code was not found in the profile's About Me section",
+		})
+		return
+	}
+
+	// Drop everything already cached about the user
+	h.cache.DeleteFunc(func(key string) bool {
+		return strings.HasSuffix(key, ":"+username) || strings.Contains(key, ":"+username+":")
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"username":  h.ids.Encode(username),
+		"opted_out": true,
+	})
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+
+	"goodreads-scraper/internal/scraper"
+)
+
+// graphQLRequest is the standard GraphQL-over-HTTP request body
+type graphQLRequest struct {
+	Query         string                 `json:"query" form:"query"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// loadStats returns reading statistics from cache, scraping on a miss
+func (h *Handler) loadStats(username string) (*scraper.ReadingStats, error) {
+	cacheKey := "stats:" + username
+	if cached, found := h.cache.Get(cacheKey); found {
+		if stats, ok := cached.(*scraper.ReadingStats); ok {
+			return stats, nil
+		}
+	}
+
+	stats, err := h.getStats(username)
+	if err != nil {
+		return nil, err
+	}
+
+	h.cache.Set(cacheKey, stats)
+	return stats, nil
+}
+
+// loadShelf returns a shelf's books from cache, scraping on a miss
+func (h *Handler) loadShelf(username, shelf string) ([]scraper.Book, error) {
+	cacheKey := "shelf:" + username + ":" + shelf
+	if cached, found := h.cache.Get(cacheKey); found {
+		if books, ok := cached.([]scraper.Book); ok {
+			return books, nil
+		}
+	}
+
+	books, err := h.scraper.GetShelf(username, shelf)
+	if err != nil {
+		return nil, err
+	}
+
+	h.cache.Set(cacheKey, books)
+	return books, nil
+}
+
+// loadTsundoku returns the tsundoku report from cache, scraping on a miss
+func (h *Handler) loadTsundoku(username string) (*scraper.TsundokuReport, error) {
+	cacheKey := "tsundoku:" + username
+	if cached, found := h.cache.Get(cacheKey); found {
+		if report, ok := cached.(*scraper.TsundokuReport); ok {
+			return report, nil
+		}
+	}
+
+	report, err := h.scraper.GetTsundoku(username)
+	if err != nil {
+		return nil, err
+	}
+
+	h.cache.Set(cacheKey, report)
+	return report, nil
+}
+
+// newGraphQLSchema builds the GraphQL schema; field names follow the REST JSON names
+func (h *Handler) newGraphQLSchema() (graphql.Schema, error) {
+	bookType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.Fields{
+			"title":         &graphql.Field{Type: graphql.String},
+			"author":        &graphql.Field{Type: graphql.String},
+			"isbn":          &graphql.Field{Type: graphql.String},
+			"rating":        &graphql.Field{Type: graphql.Int},
+			"date_read":     &graphql.Field{Type: graphql.String},
+			"date_added":    &graphql.Field{Type: graphql.DateTime},
+			"cover_url":     &graphql.Field{Type: graphql.String},
+			"goodreads_url": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	tsundokuBookType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TsundokuBook",
+		Fields: graphql.Fields{
+			"book": &graphql.Field{
+				Type: bookType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(scraper.TsundokuBook).Book, nil
+				},
+			},
+			"days_since_acquired": &graphql.Field{Type: graphql.Int},
+		},
+	})
+
+	tsundokuType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Tsundoku",
+		Fields: graphql.Fields{
+			"count":         &graphql.Field{Type: graphql.Int},
+			"owned_shelves": &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"books":         &graphql.Field{Type: graphql.NewList(tsundokuBookType)},
+			"last_updated":  &graphql.Field{Type: graphql.DateTime},
+		},
+	})
+
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"user_id": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return h.ids.Encode(p.Source.(*scraper.ReadingStats).UserID), nil
+				},
+			},
+			"username": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return h.ids.Encode(p.Source.(*scraper.ReadingStats).Username), nil
+				},
+			},
+			"total_books":       &graphql.Field{Type: graphql.Int},
+			"books_this_year":   &graphql.Field{Type: graphql.Int},
+			"currently_reading": &graphql.Field{Type: graphql.Int},
+			"average_rating":    &graphql.Field{Type: graphql.Float},
+			"total_ratings":     &graphql.Field{Type: graphql.Int},
+			"total_reviews":     &graphql.Field{Type: graphql.Int},
+			"last_updated":      &graphql.Field{Type: graphql.DateTime},
+			"recent_reads":      &graphql.Field{Type: graphql.NewList(bookType)},
+			"favorites":         &graphql.Field{Type: graphql.NewList(bookType)},
+			"study_books":       &graphql.Field{Type: graphql.NewList(bookType)},
+			"shelf": &graphql.Field{
+				Type: graphql.NewList(bookType),
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					stats := p.Source.(*scraper.ReadingStats)
+					return h.loadShelf(stats.Username, p.Args["name"].(string))
+				},
+			},
+			"tsundoku": &graphql.Field{
+				Type: tsundokuType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					stats := p.Source.(*scraper.ReadingStats)
+					return h.loadTsundoku(stats.Username)
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"username": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					username, ok := h.ids.Decode(p.Args["username"].(string))
+					if !ok {
+						return nil, errors.New("unknown user identifier")
+					}
+					if h.consent.IsOptedOut(username) {
+						return nil, errors.New("user has opted out of scraping")
+					}
+					return h.loadStats(username)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// graphQL executes GraphQL queries sent via GET query parameters or a POST body
+func (h *Handler) graphQL(schema graphql.Schema) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req graphQLRequest
+		if err := c.ShouldBind(&req); err != nil || req.Query == "" {
+			c.JSON(http.StatusBadRequest, scraper.ErrorResponse{
+				Error:   "invalid_query",
+				Message: "Request must include a GraphQL query",
+			})
+			return
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  req.Query,
+			VariableValues: req.Variables,
+			OperationName:  req.OperationName,
+		})
+
+		c.JSON(http.StatusOK, result)
+	}
+}
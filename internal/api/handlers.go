@@ -1,12 +1,16 @@
 package api
 
 import (
+	"log"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 
 	"goodreads-scraper/internal/cache"
+	"goodreads-scraper/internal/consent"
+	"goodreads-scraper/internal/freshness"
 	"goodreads-scraper/internal/middleware"
+	"goodreads-scraper/internal/obfuscate"
 	"goodreads-scraper/internal/scraper"
 	"goodreads-scraper/pkg/config"
 
@@ -15,29 +19,70 @@ import (
 
 // Handler holds dependencies for API handlers
 type Handler struct {
-	scraper scraper.Interface
-	cache   *cache.MemoryCache
+	scraper      scraper.Interface
+	cache        *cache.MemoryCache
+	freshness    *freshness.Monitor
+	deprecations *middleware.DeprecationRegistry
+	ids          obfuscate.Codec
+	consent      *consent.Registry
+}
+
+// HandlerOption configures optional Handler dependencies
+type HandlerOption func(*Handler)
+
+// WithFreshnessMonitor sets the monitor used to track data freshness SLAs
+func WithFreshnessMonitor(m *freshness.Monitor) HandlerOption {
+	return func(h *Handler) {
+		h.freshness = m
+	}
+}
+
+// WithIDCodec sets how Goodreads user IDs are presented to and accepted from clients
+func WithIDCodec(codec obfuscate.Codec) HandlerOption {
+	return func(h *Handler) {
+		h.ids = codec
+	}
+}
+
+// WithConsentRegistry sets the registry of users who opted out of scraping
+func WithConsentRegistry(registry *consent.Registry) HandlerOption {
+	return func(h *Handler) {
+		h.consent = registry
+	}
 }
 
 // NewHandler creates a new API handler
-func NewHandler(s scraper.Interface, c *cache.MemoryCache) *Handler {
-	return &Handler{
-		scraper: s,
-		cache:   c,
+func NewHandler(s scraper.Interface, c *cache.MemoryCache, opts ...HandlerOption) *Handler {
+	// An in-memory registry never fails to load
+	registry, _ := consent.NewRegistry("")
+
+	h := &Handler{
+		scraper:      s,
+		cache:        c,
+		freshness:    freshness.NewMonitor(0, nil, nil),
+		deprecations: middleware.NewDeprecationRegistry(false),
+		ids:          obfuscate.Passthrough{},
+		consent:      registry,
+	}
+
+	for _, opt := range opts {
+		opt(h)
 	}
+
+	return h
 }
 
 // SetupRoutes configures the API routes
 func (h *Handler) SetupRoutes(cfg *config.Config) *gin.Engine {
 	r := gin.Default()
 
+	// Routes registered with h.deprecations.Deprecated(...) are counted and,
+	// when configured, switched off
+	h.deprecations = middleware.NewDeprecationRegistry(cfg.DisableDeprecatedRoutes)
+
 	// Configure trusted proxies for security
 	// Parse trusted proxies from config (comma-separated)
-	trustedProxies := strings.Split(cfg.TrustedProxies, ",")
-	for i, proxy := range trustedProxies {
-		trustedProxies[i] = strings.TrimSpace(proxy)
-	}
-	r.SetTrustedProxies(trustedProxies)
+	r.SetTrustedProxies(config.SplitList(cfg.TrustedProxies))
 
 	// Add CORS headers for frontend consumption
 	r.Use(func(c *gin.Context) {
@@ -53,8 +98,19 @@ func (h *Handler) SetupRoutes(cfg *config.Config) *gin.Engine {
 		c.Next()
 	})
 
+	// Resolve opaque user tokens in :username before any handler sees it
+	r.Use(h.decodeUsername)
+
+	// Never serve or scrape users who opted out
+	r.Use(h.enforceOptOut)
+
 	// Health check
 	r.GET("/health", h.healthCheck)
+	r.GET("/health/ready", h.readinessCheck)
+
+	// API documentation
+	r.GET("/openapi.json", h.openAPI)
+	r.GET("/docs", h.docs)
 
 	// Debug endpoints
 	r.GET("/debug/:username", h.debugHTML)
@@ -63,6 +119,9 @@ func (h *Handler) SetupRoutes(cfg *config.Config) *gin.Engine {
 	// General rate limiting for all API endpoints
 	v1 := r.Group("/api/v1", middleware.RateLimitMiddleware(cfg.RateLimitPerMinute, cfg.RateLimitPerMinute))
 
+	// Library export import does not touch Goodreads
+	v1.POST("/import/goodreads-csv", h.importGoodreadsCSV)
+
 	// Apply stricter rate limiting to scraping endpoints
 	scrapeGroup := v1.Group("/")
 	scrapeGroup.Use(middleware.ScrapeRateLimitMiddleware(cfg.ScrapeRateLimit, cfg.ScrapeRateLimit))
@@ -70,8 +129,40 @@ func (h *Handler) SetupRoutes(cfg *config.Config) *gin.Engine {
 		scrapeGroup.GET("/reading-stats/:username", h.getReadingStats)
 		scrapeGroup.GET("/reading-stats/:username/favorites", h.getFavorites)
 		scrapeGroup.GET("/reading-stats/:username/study", h.getStudyBooks)
+		scrapeGroup.GET("/reading-stats/:username/tsundoku", h.getTsundoku)
 		scrapeGroup.GET("/portfolio/:username", h.getPortfolioData)
+		scrapeGroup.POST("/opt-out/:username/verify", h.verifyOptOut)
+	}
+
+	// Opt-out requests
+	v1.POST("/opt-out/:username", h.requestOptOut)
+
+	// Admin endpoints
+	admin := r.Group("/admin", middleware.AdminAuth(cfg.AdminToken))
+	admin.GET("/config", h.adminConfig(cfg))
+	admin.GET("/debug/pprof/*profile", h.pprofProfile)
+
+	// GraphQL resolves scrapes lazily, so it shares the scraping rate limits
+	schema, err := h.newGraphQLSchema()
+	if err != nil {
+		log.Fatalf("Failed to build GraphQL schema: %v", err)
 	}
+	graphQLHandler := h.graphQL(schema)
+	graphQLGroup := r.Group("/graphql",
+		middleware.RateLimitMiddleware(cfg.RateLimitPerMinute, cfg.RateLimitPerMinute),
+		middleware.ScrapeRateLimitMiddleware(cfg.ScrapeRateLimit, cfg.ScrapeRateLimit),
+	)
+	graphQLGroup.GET("", graphQLHandler)
+	graphQLGroup.POST("", graphQLHandler)
+
+	// SVG badges for READMEs, rendered from cached stats
+	badgeGroup := r.Group("/badge",
+		middleware.RateLimitMiddleware(cfg.RateLimitPerMinute, cfg.RateLimitPerMinute),
+		middleware.ScrapeRateLimitMiddleware(cfg.ScrapeRateLimit, cfg.ScrapeRateLimit),
+	)
+	badgeGroup.GET("/:username/books-read.svg", h.badge("books read", booksReadBadge))
+	badgeGroup.GET("/:username/avg-rating.svg", h.badge("avg rating", avgRatingBadge))
+	badgeGroup.GET("/:username/challenge.svg", h.badge("reading challenge", challengeBadge))
 
 	return r
 }
@@ -81,12 +172,111 @@ func (h *Handler) healthCheck(c *gin.Context) {
 	cacheStats := h.cache.Stats()
 
 	c.JSON(http.StatusOK, gin.H{
-		"status":    "healthy",
+		"status":                 "healthy",
+		"timestamp":              time.Now(),
+		"cache":                  cacheStats,
+		"deprecated_route_usage": h.deprecations.Usage(),
+	})
+}
+
+// readinessCheck reports whether tracked users' data is within the freshness SLA
+func (h *Handler) readinessCheck(c *gin.Context) {
+	violations := h.freshness.Violations(time.Now())
+
+	status := http.StatusOK
+	state := "ready"
+	if len(violations) > 0 {
+		status = http.StatusServiceUnavailable
+		state = "degraded"
+	}
+
+	for i := range violations {
+		violations[i].Username = h.ids.Encode(violations[i].Username)
+	}
+
+	c.JSON(status, gin.H{
+		"status":    state,
 		"timestamp": time.Now(),
-		"cache":     cacheStats,
+		"freshness": gin.H{
+			"sla":        h.freshness.SLA().String(),
+			"violations": violations,
+		},
 	})
 }
 
+// adminConfig returns the effective configuration with secrets redacted
+func (h *Handler) adminConfig(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"settings": cfg.Describe(),
+		})
+	}
+}
+
+// decodeUsername replaces a public user token in the :username path
+// parameter with the Goodreads user ID it stands for
+func (h *Handler) decodeUsername(c *gin.Context) {
+	for i, param := range c.Params {
+		if param.Key != "username" {
+			continue
+		}
+
+		id, ok := h.ids.Decode(param.Value)
+		if !ok {
+			c.JSON(http.StatusNotFound, scraper.ErrorResponse{
+				Error:   "unknown_user",
+				Message: "Unknown user identifier",
+			})
+			c.Abort()
+			return
+		}
+		c.Params[i].Value = id
+	}
+
+	c.Next()
+}
+
+// publicStats returns a copy of stats with user identifiers encoded for clients
+func (h *Handler) publicStats(stats *scraper.ReadingStats) *scraper.ReadingStats {
+	public := *stats
+	public.UserID = h.ids.Encode(stats.UserID)
+	public.Username = h.ids.Encode(stats.Username)
+	return &public
+}
+
+// publicTsundoku returns a copy of report with user identifiers encoded for clients
+func (h *Handler) publicTsundoku(report *scraper.TsundokuReport) *scraper.TsundokuReport {
+	public := *report
+	public.UserID = h.ids.Encode(report.UserID)
+	public.Username = h.ids.Encode(report.Username)
+	return &public
+}
+
+// getStats scrapes reading statistics, recording the outcome for freshness tracking
+func (h *Handler) getStats(username string) (*scraper.ReadingStats, error) {
+	stats, err := h.scraper.GetReadingStats(username)
+	if err != nil {
+		h.freshness.RecordFailure(username, err)
+		return nil, err
+	}
+
+	h.freshness.RecordSuccess(username, stats.LastUpdated)
+	return stats, nil
+}
+
+// setDataAge reports how old the served data is in seconds
+func setDataAge(c *gin.Context, lastUpdated time.Time) {
+	if lastUpdated.IsZero() {
+		return
+	}
+
+	age := time.Since(lastUpdated)
+	if age < 0 {
+		age = 0
+	}
+	c.Header("X-Data-Age", strconv.Itoa(int(age.Seconds())))
+}
+
 // getReadingStats returns complete reading statistics
 func (h *Handler) getReadingStats(c *gin.Context) {
 	username := c.Param("username")
@@ -96,13 +286,14 @@ func (h *Handler) getReadingStats(c *gin.Context) {
 	if cached, found := h.cache.Get(cacheKey); found {
 		if stats, ok := cached.(*scraper.ReadingStats); ok {
 			c.Header("X-Cache", "HIT")
-			c.JSON(http.StatusOK, stats)
+			setDataAge(c, stats.LastUpdated)
+			c.JSON(http.StatusOK, h.publicStats(stats))
 			return
 		}
 	}
 
 	// Scrape fresh data
-	stats, err := h.scraper.GetReadingStats(username)
+	stats, err := h.getStats(username)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, scraper.ErrorResponse{
 			Error:   "scraping_failed",
@@ -114,7 +305,8 @@ func (h *Handler) getReadingStats(c *gin.Context) {
 	// Cache the result
 	h.cache.Set(cacheKey, stats)
 	c.Header("X-Cache", "MISS")
-	c.JSON(http.StatusOK, stats)
+	setDataAge(c, stats.LastUpdated)
+	c.JSON(http.StatusOK, h.publicStats(stats))
 }
 
 // getFavorites returns only favorite books
@@ -127,7 +319,7 @@ func (h *Handler) getFavorites(c *gin.Context) {
 		if books, ok := cached.([]scraper.Book); ok {
 			c.Header("X-Cache", "HIT")
 			c.JSON(http.StatusOK, gin.H{
-				"username":  username,
+				"username":  h.ids.Encode(username),
 				"favorites": books,
 				"count":     len(books),
 			})
@@ -136,7 +328,7 @@ func (h *Handler) getFavorites(c *gin.Context) {
 	}
 
 	// Get from full stats (this will use cache if available)
-	stats, err := h.scraper.GetReadingStats(username)
+	stats, err := h.getStats(username)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, scraper.ErrorResponse{
 			Error:   "scraping_failed",
@@ -150,7 +342,7 @@ func (h *Handler) getFavorites(c *gin.Context) {
 	c.Header("X-Cache", "MISS")
 
 	c.JSON(http.StatusOK, gin.H{
-		"username":  username,
+		"username":  h.ids.Encode(username),
 		"favorites": stats.Favorites,
 		"count":     len(stats.Favorites),
 	})
@@ -166,7 +358,7 @@ func (h *Handler) getStudyBooks(c *gin.Context) {
 		if books, ok := cached.([]scraper.Book); ok {
 			c.Header("X-Cache", "HIT")
 			c.JSON(http.StatusOK, gin.H{
-				"username":    username,
+				"username":    h.ids.Encode(username),
 				"study_books": books,
 				"count":       len(books),
 			})
@@ -175,7 +367,7 @@ func (h *Handler) getStudyBooks(c *gin.Context) {
 	}
 
 	// Get from full stats (this will use cache if available)
-	stats, err := h.scraper.GetReadingStats(username)
+	stats, err := h.getStats(username)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, scraper.ErrorResponse{
 			Error:   "scraping_failed",
@@ -189,12 +381,42 @@ func (h *Handler) getStudyBooks(c *gin.Context) {
 	c.Header("X-Cache", "MISS")
 
 	c.JSON(http.StatusOK, gin.H{
-		"username":    username,
+		"username":    h.ids.Encode(username),
 		"study_books": stats.StudyBooks,
 		"count":       len(stats.StudyBooks),
 	})
 }
 
+// getTsundoku returns owned books that have not been read yet
+func (h *Handler) getTsundoku(c *gin.Context) {
+	username := c.Param("username")
+
+	// Check cache first
+	cacheKey := "tsundoku:" + username
+	if cached, found := h.cache.Get(cacheKey); found {
+		if report, ok := cached.(*scraper.TsundokuReport); ok {
+			c.Header("X-Cache", "HIT")
+			setDataAge(c, report.LastUpdated)
+			c.JSON(http.StatusOK, h.publicTsundoku(report))
+			return
+		}
+	}
+
+	report, err := h.scraper.GetTsundoku(username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, scraper.ErrorResponse{
+			Error:   "scraping_failed",
+			Message: "Failed to build tsundoku report: " + err.Error(),
+		})
+		return
+	}
+
+	// Cache the result
+	h.cache.Set(cacheKey, report)
+	c.Header("X-Cache", "MISS")
+	c.JSON(http.StatusOK, h.publicTsundoku(report))
+}
+
 // debugHTML returns HTML structure debug information
 func (h *Handler) debugHTML(c *gin.Context) {
 	username := c.Param("username")
@@ -210,7 +432,7 @@ func (h *Handler) debugHTML(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":  "Debug output written to console logs",
-		"username": username,
+		"username": h.ids.Encode(username),
 	})
 }
 
@@ -233,7 +455,7 @@ func (h *Handler) debugShelf(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":  "Shelf debug output written to console logs",
-		"username": username,
+		"username": h.ids.Encode(username),
 		"shelf":    shelf,
 	})
 }
@@ -246,12 +468,17 @@ func (h *Handler) getPortfolioData(c *gin.Context) {
 	cacheKey := "portfolio:" + username
 	if cached, found := h.cache.Get(cacheKey); found {
 		c.Header("X-Cache", "HIT")
+		if data, ok := cached.(gin.H); ok {
+			if lastUpdated, ok := data["last_updated"].(time.Time); ok {
+				setDataAge(c, lastUpdated)
+			}
+		}
 		c.JSON(http.StatusOK, cached)
 		return
 	}
 
 	// Get full stats
-	stats, err := h.scraper.GetReadingStats(username)
+	stats, err := h.getStats(username)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "scraping_failed",
@@ -262,7 +489,7 @@ func (h *Handler) getPortfolioData(c *gin.Context) {
 
 	// Create portfolio-optimized response
 	portfolioData := gin.H{
-		"username": username,
+		"username": h.ids.Encode(username),
 		"stats": gin.H{
 			"total_ratings":  stats.TotalRatings,
 			"total_reviews":  stats.TotalReviews,
@@ -279,5 +506,6 @@ func (h *Handler) getPortfolioData(c *gin.Context) {
 	// Cache the result
 	h.cache.Set(cacheKey, portfolioData)
 	c.Header("X-Cache", "MISS")
+	setDataAge(c, stats.LastUpdated)
 	c.JSON(http.StatusOK, portfolioData)
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,7 +13,10 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"goodreads-scraper/internal/cache"
+	"goodreads-scraper/internal/freshness"
+	"goodreads-scraper/internal/obfuscate"
 	"goodreads-scraper/internal/scraper"
+	"goodreads-scraper/pkg/config"
 )
 
 // MockScraper implements the scraper interface for testing
@@ -25,6 +29,21 @@ func (m *MockScraper) GetReadingStats(username string) (*scraper.ReadingStats, e
 	return args.Get(0).(*scraper.ReadingStats), args.Error(1)
 }
 
+func (m *MockScraper) GetTsundoku(username string) (*scraper.TsundokuReport, error) {
+	args := m.Called(username)
+	return args.Get(0).(*scraper.TsundokuReport), args.Error(1)
+}
+
+func (m *MockScraper) GetShelf(username, shelf string) ([]scraper.Book, error) {
+	args := m.Called(username, shelf)
+	return args.Get(0).([]scraper.Book), args.Error(1)
+}
+
+func (m *MockScraper) GetProfileBio(username string) (string, error) {
+	args := m.Called(username)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockScraper) DebugHTML(username string) error {
 	args := m.Called(username)
 	return args.Error(0)
@@ -55,6 +74,7 @@ func setupTestRouter(mockScraper *MockScraper) *gin.Engine {
 
 	// Health check
 	r.GET("/health", handler.healthCheck)
+	r.GET("/health/ready", handler.readinessCheck)
 
 	// API routes
 	v1 := r.Group("/api/v1")
@@ -62,6 +82,13 @@ func setupTestRouter(mockScraper *MockScraper) *gin.Engine {
 	v1.GET("/reading-stats/:username", handler.getReadingStats)
 	v1.GET("/reading-stats/:username/favorites", handler.getFavorites)
 	v1.GET("/reading-stats/:username/study", handler.getStudyBooks)
+	v1.GET("/reading-stats/:username/tsundoku", handler.getTsundoku)
+	v1.POST("/import/goodreads-csv", handler.importGoodreadsCSV)
+
+	// Badges
+	r.GET("/badge/:username/books-read.svg", handler.badge("books read", booksReadBadge))
+	r.GET("/badge/:username/avg-rating.svg", handler.badge("avg rating", avgRatingBadge))
+	r.GET("/badge/:username/challenge.svg", handler.badge("reading challenge", challengeBadge))
 
 	return r
 }
@@ -233,3 +260,325 @@ func TestCORSHeaders(t *testing.T) {
 	assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "GET")
 	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Content-Type")
 }
+
+func TestTsundokuHandler_Success(t *testing.T) {
+	mockScraper := &MockScraper{}
+	router := setupTestRouter(mockScraper)
+
+	report := &scraper.TsundokuReport{
+		Username:     "testuser",
+		Count:        1,
+		OwnedShelves: []string{"owned"},
+		Books: []scraper.TsundokuBook{
+			{Book: scraper.Book{Title: "Unread Book", Author: "Some Author"}, DaysSinceAcquired: 400},
+		},
+		LastUpdated: time.Now(),
+	}
+
+	mockScraper.On("GetTsundoku", "testuser").Return(report, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/reading-stats/testuser/tsundoku", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(1), response["count"])
+	books := response["books"].([]interface{})
+	assert.Len(t, books, 1)
+	assert.Equal(t, float64(400), books[0].(map[string]interface{})["days_since_acquired"])
+
+	mockScraper.AssertExpectations(t)
+}
+
+func TestDataAgeHeader(t *testing.T) {
+	mockScraper := &MockScraper{}
+	router := setupTestRouter(mockScraper)
+
+	stats := &scraper.ReadingStats{
+		Username:    "testuser",
+		LastUpdated: time.Now().Add(-90 * time.Second),
+	}
+	mockScraper.On("GetReadingStats", "testuser").Return(stats, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/reading-stats/testuser", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "90", w.Header().Get("X-Data-Age"))
+}
+
+func TestBadgeHandlers(t *testing.T) {
+	mockScraper := &MockScraper{}
+	router := setupTestRouter(mockScraper)
+
+	stats := &scraper.ReadingStats{
+		Username:      "testuser",
+		TotalBooks:    150,
+		BooksThisYear: 30,
+		AverageRating: 4.12,
+		LastUpdated:   time.Now(),
+	}
+	mockScraper.On("GetReadingStats", "testuser").Return(stats, nil).Once()
+
+	tests := []struct {
+		path    string
+		message string
+		color   string
+	}{
+		{"/badge/testuser/books-read.svg", "150", badgeBlue},
+		{"/badge/testuser/avg-rating.svg", "4.12/5", badgeBrightGreen},
+		{"/badge/testuser/challenge.svg", "30", badgeBlue},
+		{"/badge/testuser/challenge.svg?goal=50", "30/50", badgeYellowGreen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, 200, w.Code)
+			assert.Equal(t, "image/svg+xml; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Header().Get("Cache-Control"), "max-age=")
+			assert.Contains(t, w.Body.String(), ">"+tt.message+"</text>")
+			assert.Contains(t, w.Body.String(), `fill="`+tt.color+`"`)
+		})
+	}
+
+	// Stats are scraped once and served from cache afterwards
+	mockScraper.AssertExpectations(t)
+}
+
+func TestBadgeHandler_ScrapeFailure(t *testing.T) {
+	mockScraper := &MockScraper{}
+	router := setupTestRouter(mockScraper)
+
+	mockScraper.On("GetReadingStats", "testuser").Return((*scraper.ReadingStats)(nil), assert.AnError)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/badge/testuser/books-read.svg", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Body.String(), ">unavailable</text>")
+}
+
+func TestReadinessHandler_FreshnessViolation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockScraper := &MockScraper{}
+	monitor := freshness.NewMonitor(time.Hour, []string{"tracked"}, nil)
+	monitor.RecordSuccess("tracked", time.Now().Add(-2*time.Hour))
+
+	handler := NewHandler(mockScraper, cache.NewMemoryCache(time.Hour), WithFreshnessMonitor(monitor))
+	r := gin.New()
+	r.GET("/health/ready", handler.readinessCheck)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/health/ready", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "degraded", response["status"])
+
+	violations := response["freshness"].(map[string]interface{})["violations"].([]interface{})
+	assert.Len(t, violations, 1)
+}
+
+func TestAdminConfigHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Load()
+	cfg.AdminToken = "secret"
+
+	handler := NewHandler(&MockScraper{}, cache.NewMemoryCache(time.Hour))
+	router := handler.SetupRoutes(cfg)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/config", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/admin/config", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+
+	var response struct {
+		Settings []config.Setting `json:"settings"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.Settings)
+	assert.NotContains(t, w.Body.String(), "secret")
+}
+
+func TestPprofRequiresAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Load()
+	cfg.AdminToken = "secret"
+
+	handler := NewHandler(&MockScraper{}, cache.NewMemoryCache(time.Hour))
+	router := handler.SetupRoutes(cfg)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/debug/pprof/heap", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	for _, path := range []string{"/admin/debug/pprof/", "/admin/debug/pprof/goroutine?debug=1"} {
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		router.ServeHTTP(w, req)
+		assert.Equal(t, 200, w.Code, path)
+		assert.Contains(t, w.Body.String(), "goroutine", path)
+	}
+}
+
+func TestImportGoodreadsCSV(t *testing.T) {
+	mockScraper := &MockScraper{}
+	router := setupTestRouter(mockScraper)
+
+	export := "Book Id,Title,Author,My Rating,Date Read,Bookshelves,Exclusive Shelf,My Review\n" +
+		"123,Dune,Frank Herbert,5,2024/03/01,favorites,read,\n"
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/import/goodreads-csv?username=testuser", strings.NewReader(export))
+	req.Header.Set("Content-Type", "text/csv")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	// Imported data is served without scraping
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/reading-stats/testuser", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), response["total_books"])
+
+	mockScraper.AssertNotCalled(t, "GetReadingStats", "testuser")
+}
+
+func TestImportGoodreadsCSV_Invalid(t *testing.T) {
+	router := setupTestRouter(&MockScraper{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/import/goodreads-csv", strings.NewReader("x"))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/v1/import/goodreads-csv?username=testuser", strings.NewReader("name,age\n"))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_export")
+}
+
+func TestIDObfuscation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockScraper := &MockScraper{}
+	codec := obfuscate.NewHMAC("secret", []string{"101839711-kaine"})
+	handler := NewHandler(mockScraper, cache.NewMemoryCache(time.Hour), WithIDCodec(codec))
+
+	r := gin.New()
+	r.Use(handler.decodeUsername)
+	r.GET("/api/v1/reading-stats/:username", handler.getReadingStats)
+
+	stats := &scraper.ReadingStats{
+		UserID:      "101839711-kaine",
+		Username:    "101839711-kaine",
+		LastUpdated: time.Now(),
+	}
+	mockScraper.On("GetReadingStats", "101839711-kaine").Return(stats, nil).Once()
+
+	token := codec.Encode("101839711-kaine")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/reading-stats/"+token, nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.NotContains(t, w.Body.String(), "101839711")
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, token, response["user_id"])
+	assert.Equal(t, token, response["username"])
+
+	// Cached stats keep the raw ID internally
+	cached, _ := handler.cache.Get("stats:101839711-kaine")
+	assert.Equal(t, "101839711-kaine", cached.(*scraper.ReadingStats).UserID)
+
+	// Unknown tokens are rejected
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/reading-stats/u_doesnotexist", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockScraper.AssertExpectations(t)
+}
+
+func TestOptOutFlow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockScraper := &MockScraper{}
+	handler := NewHandler(mockScraper, cache.NewMemoryCache(time.Hour))
+
+	r := gin.New()
+	r.Use(handler.enforceOptOut)
+	r.GET("/api/v1/reading-stats/:username", handler.getReadingStats)
+	r.POST("/api/v1/opt-out/:username", handler.requestOptOut)
+	r.POST("/api/v1/opt-out/:username/verify", handler.verifyOptOut)
+
+	handler.cache.Set("stats:42-reader", &scraper.ReadingStats{Username: "42-reader"})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/opt-out/42-reader", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	var started map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+	code := started["code"].(string)
+
+	mockScraper.On("GetProfileBio", "42-reader").Return("Books! "+code, nil).Once()
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/v1/opt-out/42-reader/verify", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+
+	// Cached data is purged and further requests are refused
+	_, found := handler.cache.Get("stats:42-reader")
+	assert.False(t, found)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/v1/reading-stats/42-reader", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "user_opted_out")
+
+	mockScraper.AssertExpectations(t)
+}
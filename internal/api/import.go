@@ -0,0 +1,88 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"goodreads-scraper/internal/scraper"
+)
+
+// maxImportBytes bounds the size of an uploaded library export
+const maxImportBytes = 20 << 20
+
+// importGoodreadsCSV ingests a Goodreads library export CSV, either as a
+// multipart "file" upload or as a raw text/csv body, and caches the result
+// so reading-stats endpoints serve it without scraping
+func (h *Handler) importGoodreadsCSV(c *gin.Context) {
+	username := c.Query("username")
+	if username == "" {
+		username = c.PostForm("username")
+	}
+	if username == "" {
+		c.JSON(http.StatusBadRequest, scraper.ErrorResponse{
+			Error:   "missing_username",
+			Message: "The username query or form parameter is required",
+		})
+		return
+	}
+
+	username, ok := h.ids.Decode(username)
+	if !ok {
+		c.JSON(http.StatusNotFound, scraper.ErrorResponse{
+			Error:   "unknown_user",
+			Message: "Unknown user identifier",
+		})
+		return
+	}
+
+	if h.consent.IsOptedOut(username) {
+		c.JSON(http.StatusForbidden, scraper.ErrorResponse{
+			Error:   "user_opted_out",
+			Message: "This Goodreads user has opted out of this service",
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+
+	var body io.Reader = c.Request.Body
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, scraper.ErrorResponse{
+				Error:   "invalid_export",
+				Message: "Failed to read uploaded file: " + err.Error(),
+			})
+			return
+		}
+		defer f.Close()
+		body = f
+	}
+
+	stats, err := scraper.ParseGoodreadsCSV(body, username)
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		c.JSON(status, scraper.ErrorResponse{
+			Error:   "invalid_export",
+			Message: "Failed to import Goodreads export: " + err.Error(),
+		})
+		return
+	}
+
+	// Replace cached data, dropping views derived from the old stats
+	h.cache.Set("stats:"+username, stats)
+	for _, prefix := range []string{"portfolio:", "favorites:", "study:"} {
+		h.cache.Delete(prefix + username)
+	}
+	h.freshness.RecordSuccess(username, stats.LastUpdated)
+
+	c.JSON(http.StatusCreated, h.publicStats(stats))
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"goodreads-scraper/internal/cache"
+	"goodreads-scraper/pkg/config"
+)
+
+func TestOpenAPISpec_CoversAllRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var spec struct {
+		Paths map[string]map[string]interface{} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(openAPISpec, &spec))
+
+	handler := NewHandler(&MockScraper{}, cache.NewMemoryCache(time.Hour))
+	router := handler.SetupRoutes(config.Load())
+
+	// Convert gin's :param and *wildcard syntax to OpenAPI's {param}
+	param := regexp.MustCompile(`[:*](\w+)`)
+	for _, route := range router.Routes() {
+		if route.Path == "/openapi.json" || route.Path == "/docs" {
+			continue
+		}
+
+		path := param.ReplaceAllString(route.Path, "{$1}")
+		operations, ok := spec.Paths[path]
+		if assert.True(t, ok, "route %s missing from OpenAPI spec", path) {
+			assert.Contains(t, operations, strings.ToLower(route.Method), "%s %s missing from OpenAPI spec", route.Method, path)
+		}
+	}
+}
+
+func TestDocsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	handler := NewHandler(&MockScraper{}, cache.NewMemoryCache(time.Hour))
+	router := handler.SetupRoutes(config.Load())
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Contains(t, w.Body.String(), "/openapi.json")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/openapi.json", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.True(t, json.Valid(w.Body.Bytes()))
+}
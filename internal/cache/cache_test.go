@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -125,3 +126,21 @@ func TestMemoryCache_ConcurrentAccess(t *testing.T) {
 	assert.True(t, found)
 	assert.NotNil(t, value)
 }
+
+func TestMemoryCache_DeleteFunc(t *testing.T) {
+	cache := NewMemoryCache(1 * time.Hour)
+
+	cache.Set("stats:alice", "a")
+	cache.Set("shelf:alice:read", "b")
+	cache.Set("stats:bob", "c")
+
+	removed := cache.DeleteFunc(func(key string) bool {
+		return strings.Contains(key, ":alice")
+	})
+
+	assert.Equal(t, 2, removed)
+	_, found := cache.Get("stats:alice")
+	assert.False(t, found)
+	_, found = cache.Get("stats:bob")
+	assert.True(t, found)
+}
@@ -69,6 +69,21 @@ func (c *MemoryCache) Delete(key string) {
 	delete(c.data, key)
 }
 
+// DeleteFunc removes every key for which match returns true and reports how many were removed
+func (c *MemoryCache) DeleteFunc(match func(key string) bool) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	removed := 0
+	for key := range c.data {
+		if match(key) {
+			delete(c.data, key)
+			removed++
+		}
+	}
+	return removed
+}
+
 // cleanup removes expired items from the cache
 func (c *MemoryCache) cleanup() {
 	ticker := time.NewTicker(time.Minute)
@@ -0,0 +1,171 @@
+package consent
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// codeTTL is how long a user has to place the verification code in their bio
+const codeTTL = 24 * time.Hour
+
+// ErrNoPendingRequest is returned when verifying without a live opt-out request
+var ErrNoPendingRequest = errors.New("no pending opt-out request")
+
+// pendingRequest is an opt-out awaiting bio verification
+type pendingRequest struct {
+	code      string
+	expiresAt time.Time
+}
+
+// OptOut records a verified exclusion
+type OptOut struct {
+	Username   string    `json:"username"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
+
+// Registry tracks users who asked not to be scraped. Verified opt-outs are
+// persisted to a JSON file so they survive restarts.
+type Registry struct {
+	path string
+
+	mu       sync.RWMutex
+	pending  map[string]pendingRequest
+	optedOut map[string]OptOut
+}
+
+// NewRegistry loads the registry from path; an empty path keeps it in memory
+func NewRegistry(path string) (*Registry, error) {
+	r := &Registry{
+		path:     path,
+		pending:  make(map[string]pendingRequest),
+		optedOut: make(map[string]OptOut),
+	}
+
+	if path == "" {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read opt-out registry: %w", err)
+	}
+
+	var entries []OptOut
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse opt-out registry: %w", err)
+	}
+	for _, entry := range entries {
+		r.optedOut[entry.Username] = entry
+	}
+
+	return r, nil
+}
+
+// Begin starts an opt-out request and returns the code the user must place in their bio
+func (r *Registry) Begin(username string) (string, time.Time, error) {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate code: %w", err)
+	}
+
+	code := "optout-" + hex.EncodeToString(buf)
+	expiresAt := time.Now().Add(codeTTL)
+
+	r.mu.Lock()
+	r.pending[username] = pendingRequest{code: code, expiresAt: expiresAt}
+	r.mu.Unlock()
+
+	return code, expiresAt, nil
+}
+
+// Verify completes an opt-out if bio contains the pending code; it reports
+// whether the user is now opted out
+func (r *Registry) Verify(username, bio string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	req, exists := r.pending[username]
+	if !exists || time.Now().After(req.expiresAt) {
+		delete(r.pending, username)
+		return false, ErrNoPendingRequest
+	}
+
+	if !strings.Contains(bio, req.code) {
+		return false, nil
+	}
+
+	delete(r.pending, username)
+	r.optedOut[username] = OptOut{Username: username, VerifiedAt: time.Now()}
+
+	if err := r.save(); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// IsOptedOut reports whether username must not be scraped
+func (r *Registry) IsOptedOut(username string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.optedOut[username]
+	return exists
+}
+
+// List returns all verified opt-outs sorted by username
+func (r *Registry) List() []OptOut {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]OptOut, 0, len(r.optedOut))
+	for _, entry := range r.optedOut {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Username < entries[j].Username
+	})
+	return entries
+}
+
+// save writes verified opt-outs to disk; callers must hold mu
+func (r *Registry) save() error {
+	if r.path == "" {
+		return nil
+	}
+
+	entries := make([]OptOut, 0, len(r.optedOut))
+	for _, entry := range r.optedOut {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Username < entries[j].Username
+	})
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode opt-out registry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create opt-out registry directory: %w", err)
+	}
+
+	// Write atomically so a crash can't lose existing opt-outs
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write opt-out registry: %w", err)
+	}
+	return os.Rename(tmp, r.path)
+}
@@ -0,0 +1,44 @@
+package consent
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_OptOutFlow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "opt-out.json")
+	registry, err := NewRegistry(path)
+	require.NoError(t, err)
+
+	code, _, err := registry.Begin("42-reader")
+	require.NoError(t, err)
+	assert.Contains(t, code, "optout-")
+
+	// Bio without the code does not verify
+	ok, err := registry.Verify("42-reader", "I like books")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, registry.IsOptedOut("42-reader"))
+
+	ok, err = registry.Verify("42-reader", "I like books. "+code)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, registry.IsOptedOut("42-reader"))
+
+	// Opt-outs survive a restart
+	reloaded, err := NewRegistry(path)
+	require.NoError(t, err)
+	assert.True(t, reloaded.IsOptedOut("42-reader"))
+	assert.Len(t, reloaded.List(), 1)
+}
+
+func TestRegistry_VerifyWithoutRequest(t *testing.T) {
+	registry, err := NewRegistry("")
+	require.NoError(t, err)
+
+	_, err = registry.Verify("42-reader", "anything")
+	assert.ErrorIs(t, err, ErrNoPendingRequest)
+}
@@ -0,0 +1,163 @@
+package freshness
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"goodreads-scraper/internal/notify"
+)
+
+// EventSLAViolated is emitted when a tracked user's data exceeds the SLA
+const EventSLAViolated = "freshness_sla_violated"
+
+// Monitor tracks how fresh each user's scraped data is against an SLA
+type Monitor struct {
+	sla      time.Duration
+	tracked  map[string]bool
+	notifier notify.Notifier
+	started  time.Time
+
+	mu    sync.Mutex
+	users map[string]*userState
+}
+
+// userState holds the scrape history for one user
+type userState struct {
+	lastSuccess time.Time
+	failures    int
+	lastError   string
+	alerted     bool
+}
+
+// Violation describes a tracked user whose data is older than the SLA
+type Violation struct {
+	Username            string    `json:"username"`
+	LastSuccess         time.Time `json:"last_success,omitempty"`
+	Age                 string    `json:"age"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+}
+
+// NewMonitor creates a freshness monitor; a zero SLA disables violations
+func NewMonitor(sla time.Duration, trackedUsers []string, notifier notify.Notifier) *Monitor {
+	if notifier == nil {
+		notifier = notify.LogNotifier{}
+	}
+
+	tracked := make(map[string]bool, len(trackedUsers))
+	for _, username := range trackedUsers {
+		tracked[username] = true
+	}
+
+	return &Monitor{
+		sla:      sla,
+		tracked:  tracked,
+		notifier: notifier,
+		started:  time.Now(),
+		users:    make(map[string]*userState),
+	}
+}
+
+// SLA returns the configured freshness SLA
+func (m *Monitor) SLA() time.Duration {
+	return m.sla
+}
+
+// RecordSuccess notes a successful scrape for a user
+func (m *Monitor) RecordSuccess(username string, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := m.state(username)
+	state.lastSuccess = at
+	state.failures = 0
+	state.lastError = ""
+	state.alerted = false
+}
+
+// RecordFailure notes a failed scrape and emits an event the first time a
+// tracked user's data falls outside the SLA
+func (m *Monitor) RecordFailure(username string, err error) {
+	m.mu.Lock()
+	state := m.state(username)
+	state.failures++
+	if err != nil {
+		state.lastError = err.Error()
+	}
+
+	age := time.Since(m.since(state))
+	shouldAlert := m.sla > 0 && m.tracked[username] && age > m.sla && !state.alerted
+	if shouldAlert {
+		state.alerted = true
+	}
+	failures := state.failures
+	lastError := state.lastError
+	m.mu.Unlock()
+
+	if shouldAlert {
+		m.notifier.Notify(notify.Event{
+			Type:     EventSLAViolated,
+			Username: username,
+			Message:  fmt.Sprintf("data is %s old, exceeding the %s freshness SLA", age.Round(time.Second), m.sla),
+			Details: map[string]interface{}{
+				"consecutive_failures": failures,
+				"last_error":           lastError,
+			},
+			Timestamp: time.Now(),
+		})
+	}
+}
+
+// Violations lists tracked users whose data is older than the SLA
+func (m *Monitor) Violations(now time.Time) []Violation {
+	violations := []Violation{}
+	if m.sla <= 0 {
+		return violations
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for username := range m.tracked {
+		state := m.state(username)
+		age := now.Sub(m.since(state))
+		if age <= m.sla {
+			continue
+		}
+
+		violations = append(violations, Violation{
+			Username:            username,
+			LastSuccess:         state.lastSuccess,
+			Age:                 age.Round(time.Second).String(),
+			ConsecutiveFailures: state.failures,
+			LastError:           state.lastError,
+		})
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].Username < violations[j].Username
+	})
+
+	return violations
+}
+
+// state returns the state for a user, creating it if needed; callers must hold mu
+func (m *Monitor) state(username string) *userState {
+	state, exists := m.users[username]
+	if !exists {
+		state = &userState{}
+		m.users[username] = state
+	}
+	return state
+}
+
+// since returns the point data age is measured from; users never scraped
+// successfully are measured from monitor start
+func (m *Monitor) since(state *userState) time.Time {
+	if state.lastSuccess.IsZero() {
+		return m.started
+	}
+	return state.lastSuccess
+}
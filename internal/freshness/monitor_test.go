@@ -0,0 +1,61 @@
+package freshness
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"goodreads-scraper/internal/notify"
+)
+
+// recordingNotifier captures events for assertions
+type recordingNotifier struct {
+	events []notify.Event
+}
+
+func (r *recordingNotifier) Notify(event notify.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestMonitor_ViolationAndAlert(t *testing.T) {
+	notifier := &recordingNotifier{}
+	monitor := NewMonitor(time.Hour, []string{"tracked"}, notifier)
+
+	monitor.RecordSuccess("tracked", time.Now().Add(-2*time.Hour))
+	monitor.RecordFailure("tracked", errors.New("timeout"))
+	monitor.RecordFailure("tracked", errors.New("timeout"))
+
+	// Only one alert per violation
+	assert.Len(t, notifier.events, 1)
+	assert.Equal(t, EventSLAViolated, notifier.events[0].Type)
+	assert.Equal(t, "tracked", notifier.events[0].Username)
+
+	violations := monitor.Violations(time.Now())
+	assert.Len(t, violations, 1)
+	assert.Equal(t, 2, violations[0].ConsecutiveFailures)
+	assert.Equal(t, "timeout", violations[0].LastError)
+
+	// A successful scrape clears the violation
+	monitor.RecordSuccess("tracked", time.Now())
+	assert.Empty(t, monitor.Violations(time.Now()))
+}
+
+func TestMonitor_UntrackedUsersNeverAlert(t *testing.T) {
+	notifier := &recordingNotifier{}
+	monitor := NewMonitor(time.Hour, nil, notifier)
+
+	monitor.RecordSuccess("someone", time.Now().Add(-2*time.Hour))
+	monitor.RecordFailure("someone", errors.New("blocked"))
+
+	assert.Empty(t, notifier.events)
+	assert.Empty(t, monitor.Violations(time.Now()))
+}
+
+func TestMonitor_DisabledSLA(t *testing.T) {
+	monitor := NewMonitor(0, []string{"tracked"}, nil)
+
+	assert.Empty(t, monitor.Violations(time.Now().Add(24*time.Hour)))
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation describes a deprecated route and what replaces it
+type Deprecation struct {
+	Since     time.Time // when the route was deprecated
+	Sunset    time.Time // when the route will be removed (optional)
+	Successor string    // URL of the replacement route (optional)
+	Message   string    // human-readable migration hint (optional)
+}
+
+// DeprecationRegistry counts usage of deprecated routes and can disable them
+type DeprecationRegistry struct {
+	mu      sync.Mutex
+	usage   map[string]int64
+	disable bool
+}
+
+// NewDeprecationRegistry creates a registry; when disable is true deprecated
+// routes answer 410 Gone instead of being served
+func NewDeprecationRegistry(disable bool) *DeprecationRegistry {
+	return &DeprecationRegistry{
+		usage:   make(map[string]int64),
+		disable: disable,
+	}
+}
+
+// Deprecated marks a route as deprecated with Deprecation, Sunset and Link headers
+func (r *DeprecationRegistry) Deprecated(d Deprecation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r.mu.Lock()
+		r.usage[c.Request.Method+" "+c.FullPath()]++
+		r.mu.Unlock()
+
+		if d.Since.IsZero() {
+			c.Header("Deprecation", "true")
+		} else {
+			c.Header("Deprecation", fmt.Sprintf("@%d", d.Since.Unix()))
+		}
+		if !d.Sunset.IsZero() {
+			c.Header("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if d.Successor != "" {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", d.Successor))
+		}
+
+		if r.disable {
+			message := "This endpoint has been deprecated and is disabled on this server."
+			if d.Message != "" {
+				message += " " + d.Message
+			}
+
+			c.JSON(http.StatusGone, gin.H{
+				"error":     "route_deprecated",
+				"message":   message,
+				"successor": d.Successor,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Usage returns request counts per deprecated route
+func (r *DeprecationRegistry) Usage() map[string]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usage := make(map[string]int64, len(r.usage))
+	for route, count := range r.usage {
+		usage[route] = count
+	}
+	return usage
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecated_SetsHeadersAndCountsUsage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	registry := NewDeprecationRegistry(false)
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	r := gin.New()
+	r.GET("/old/:id", registry.Deprecated(Deprecation{
+		Since:     since,
+		Sunset:    sunset,
+		Successor: "/new",
+	}), func(c *gin.Context) {
+		c.JSON(200, gin.H{"status": "ok"})
+	})
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/old/1", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "@1735689600", w.Header().Get("Deprecation"))
+		assert.Equal(t, "Tue, 01 Jul 2025 00:00:00 GMT", w.Header().Get("Sunset"))
+		assert.Equal(t, `</new>; rel="successor-version"`, w.Header().Get("Link"))
+	}
+
+	assert.Equal(t, map[string]int64{"GET /old/:id": 2}, registry.Usage())
+}
+
+func TestDeprecated_Disabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	registry := NewDeprecationRegistry(true)
+
+	r := gin.New()
+	r.GET("/old", registry.Deprecated(Deprecation{Successor: "/new"}), func(c *gin.Context) {
+		c.JSON(200, gin.H{"status": "ok"})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/old", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Contains(t, w.Body.String(), "route_deprecated")
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"log"
+	"time"
+)
+
+// Event describes something operators may want to be told about
+type Event struct {
+	Type      string                 `json:"type"`
+	Username  string                 `json:"username,omitempty"`
+	Message   string                 `json:"message"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// Notifier delivers events to an external sink
+type Notifier interface {
+	Notify(event Event) error
+}
+
+// LogNotifier writes events to the application log
+type LogNotifier struct{}
+
+// Notify logs the event
+func (LogNotifier) Notify(event Event) error {
+	log.Printf("Event %s for %s: %s", event.Type, event.Username, event.Message)
+	return nil
+}
+
+// Multi fans an event out to several notifiers
+type Multi []Notifier
+
+// Notify delivers the event to every notifier, returning the last error seen
+func (m Multi) Notify(event Event) error {
+	var lastErr error
+	for _, n := range m {
+		if err := n.Notify(event); err != nil {
+			log.Printf("Warning: failed to deliver %s event: %v", event.Type, err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
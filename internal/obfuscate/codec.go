@@ -0,0 +1,85 @@
+package obfuscate
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"strings"
+	"sync"
+)
+
+// tokenPrefix marks opaque user tokens so they can't be mistaken for IDs
+const tokenPrefix = "u_"
+
+// Codec converts between Goodreads user IDs and the identifiers shown to clients
+type Codec interface {
+	// Encode returns the public identifier for a Goodreads user ID
+	Encode(id string) string
+	// Decode resolves a public identifier back to a Goodreads user ID
+	Decode(public string) (string, bool)
+}
+
+// Passthrough exposes Goodreads user IDs unchanged
+type Passthrough struct{}
+
+// Encode returns the ID unchanged
+func (Passthrough) Encode(id string) string { return id }
+
+// Decode returns the identifier unchanged
+func (Passthrough) Decode(public string) (string, bool) { return public, true }
+
+// HMAC replaces user IDs with truncated HMAC-SHA256 tokens. Tokens can't be
+// reversed, so the codec remembers every ID it has encoded; IDs that should
+// resolve before they are first served must be registered up front.
+type HMAC struct {
+	key []byte
+
+	mu      sync.RWMutex
+	reverse map[string]string
+}
+
+// NewHMAC creates an HMAC codec keyed by secret, pre-registering known IDs
+func NewHMAC(secret string, known []string) *HMAC {
+	codec := &HMAC{
+		key:     []byte(secret),
+		reverse: make(map[string]string),
+	}
+
+	for _, id := range known {
+		codec.Encode(id)
+	}
+
+	return codec
+}
+
+// Encode returns the stable token for id
+func (h *HMAC) Encode(id string) string {
+	if id == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, h.key)
+	mac.Write([]byte(id))
+	sum := mac.Sum(nil)[:15]
+	token := tokenPrefix + strings.ToLower(base32.StdEncoding.EncodeToString(sum))
+
+	h.mu.Lock()
+	h.reverse[token] = id
+	h.mu.Unlock()
+
+	return token
+}
+
+// Decode resolves a token to the user ID it was issued for. Raw IDs are
+// accepted too, since the caller already knows them.
+func (h *HMAC) Decode(public string) (string, bool) {
+	if !strings.HasPrefix(public, tokenPrefix) {
+		return public, true
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	id, ok := h.reverse[public]
+	return id, ok
+}
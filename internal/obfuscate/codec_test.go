@@ -0,0 +1,44 @@
+package obfuscate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMAC_RoundTrip(t *testing.T) {
+	codec := NewHMAC("secret", nil)
+
+	token := codec.Encode("101839711-kaine")
+	assert.True(t, strings.HasPrefix(token, "u_"))
+	assert.NotContains(t, token, "101839711")
+	assert.Equal(t, token, codec.Encode("101839711-kaine"), "tokens are stable")
+
+	id, ok := codec.Decode(token)
+	assert.True(t, ok)
+	assert.Equal(t, "101839711-kaine", id)
+}
+
+func TestHMAC_KnownAndUnknownTokens(t *testing.T) {
+	issuer := NewHMAC("secret", nil)
+	token := issuer.Encode("42-reader")
+
+	// A fresh codec resolves pre-registered IDs but not unseen tokens
+	codec := NewHMAC("secret", []string{"42-reader"})
+	id, ok := codec.Decode(token)
+	assert.True(t, ok)
+	assert.Equal(t, "42-reader", id)
+
+	_, ok = codec.Decode("u_unknown")
+	assert.False(t, ok)
+
+	// Different secrets produce different tokens
+	assert.NotEqual(t, token, NewHMAC("other", nil).Encode("42-reader"))
+}
+
+func TestHMAC_RawIDsPassThroughOnInput(t *testing.T) {
+	id, ok := NewHMAC("secret", nil).Decode("42-reader")
+	assert.True(t, ok)
+	assert.Equal(t, "42-reader", id)
+}
@@ -0,0 +1,164 @@
+package scraper
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidExport is returned when an upload is not a Goodreads library export
+var ErrInvalidExport = errors.New("not a Goodreads library export")
+
+// recentReadsLimit caps how many finished books are listed as recent reads
+const recentReadsLimit = 10
+
+// ParseGoodreadsCSV converts an official Goodreads library export into
+// ReadingStats, so users can skip scraping entirely
+func ParseGoodreadsCSV(r io.Reader, username string) (*ReadingStats, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for _, required := range []string{"Title", "Author", "Exclusive Shelf"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing %q column", ErrInvalidExport, required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	stats := &ReadingStats{
+		Username:    username,
+		LastUpdated: time.Now(),
+	}
+
+	type readBook struct {
+		book   Book
+		readAt time.Time
+	}
+	var read []readBook
+	ratingSum := 0
+	thisYear := time.Now().Year()
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+		}
+
+		book := Book{
+			Title:  field(record, "Title"),
+			Author: field(record, "Author"),
+			ISBN:   cleanExportISBN(field(record, "ISBN13"), field(record, "ISBN")),
+		}
+		if book.Title == "" {
+			continue
+		}
+
+		if id := field(record, "Book Id"); id != "" {
+			book.GoodreadsURL = "https://www.goodreads.com/book/show/" + id
+		}
+
+		if rating, err := strconv.Atoi(field(record, "My Rating")); err == nil && rating > 0 {
+			book.Rating = rating
+			stats.TotalRatings++
+			ratingSum += rating
+		}
+
+		if field(record, "My Review") != "" {
+			stats.TotalReviews++
+		}
+
+		if added, ok := parseExportDate(field(record, "Date Added")); ok {
+			book.DateAdded = &added
+		}
+
+		readAt, hasReadDate := parseExportDate(field(record, "Date Read"))
+		if hasReadDate {
+			book.DateRead = readAt.Format("Jan 02, 2006")
+		}
+
+		switch field(record, "Exclusive Shelf") {
+		case "read":
+			stats.TotalBooks++
+			if hasReadDate && readAt.Year() == thisYear {
+				stats.BooksThisYear++
+			}
+			read = append(read, readBook{book: book, readAt: readAt})
+		case "currently-reading":
+			stats.CurrentlyReading++
+		}
+
+		for _, shelf := range strings.Split(field(record, "Bookshelves"), ",") {
+			switch strings.TrimSpace(shelf) {
+			case "favorites":
+				stats.Favorites = append(stats.Favorites, book)
+			case "study":
+				stats.StudyBooks = append(stats.StudyBooks, book)
+			}
+		}
+	}
+
+	if stats.TotalRatings > 0 {
+		stats.AverageRating = float64(ratingSum) / float64(stats.TotalRatings)
+	}
+
+	// Most recently finished first; undated reads sort last
+	sort.SliceStable(read, func(i, j int) bool {
+		return read[i].readAt.After(read[j].readAt)
+	})
+	for i := 0; i < len(read) && i < recentReadsLimit; i++ {
+		if read[i].readAt.IsZero() {
+			break
+		}
+		stats.RecentReads = append(stats.RecentReads, read[i].book)
+	}
+
+	return stats, nil
+}
+
+// parseExportDate parses the yyyy/mm/dd dates used in library exports
+func parseExportDate(text string) (time.Time, bool) {
+	if text == "" {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse("2006/01/02", text)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// cleanExportISBN strips the ="..." spreadsheet quoting Goodreads wraps
+// ISBNs in, preferring ISBN-13
+func cleanExportISBN(values ...string) string {
+	for _, value := range values {
+		value = strings.Trim(strings.TrimPrefix(value, "="), `"`)
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoodreadsCSV(t *testing.T) {
+	year := time.Now().Year()
+	export := "Book Id,Title,Author,ISBN,ISBN13,My Rating,Date Read,Date Added,Bookshelves,Exclusive Shelf,My Review\n" +
+		fmt.Sprintf(`123,Dune,Frank Herbert,"=""0441013597""","=""9780441013593""",5,%d/03/01,%d/01/15,favorites,read,Loved it`+"\n", year, year) +
+		`456,SICP,Harold Abelson,"=""""","=""""",4,2019/06/30,2019/01/01,"study, favorites",read,` + "\n" +
+		`789,Unread Tome,Someone,,,0,,2020/02/02,,to-read,` + "\n" +
+		`321,In Progress,Somebody,,,0,,2021/02/02,,currently-reading,` + "\n"
+
+	stats, err := ParseGoodreadsCSV(strings.NewReader(export), "testuser")
+	require.NoError(t, err)
+
+	assert.Equal(t, "testuser", stats.Username)
+	assert.Equal(t, 2, stats.TotalBooks)
+	assert.Equal(t, 1, stats.BooksThisYear)
+	assert.Equal(t, 1, stats.CurrentlyReading)
+	assert.Equal(t, 2, stats.TotalRatings)
+	assert.Equal(t, 1, stats.TotalReviews)
+	assert.Equal(t, 4.5, stats.AverageRating)
+
+	assert.Len(t, stats.Favorites, 2)
+	assert.Equal(t, "9780441013593", stats.Favorites[0].ISBN)
+	assert.Equal(t, "https://www.goodreads.com/book/show/123", stats.Favorites[0].GoodreadsURL)
+	assert.Len(t, stats.StudyBooks, 1)
+	assert.Equal(t, "SICP", stats.StudyBooks[0].Title)
+
+	assert.Len(t, stats.RecentReads, 2)
+	assert.Equal(t, "Dune", stats.RecentReads[0].Title)
+	assert.Equal(t, fmt.Sprintf("Mar 01, %d", year), stats.RecentReads[0].DateRead)
+}
+
+func TestParseGoodreadsCSV_NotAnExport(t *testing.T) {
+	_, err := ParseGoodreadsCSV(strings.NewReader("name,age\nbob,3\n"), "testuser")
+	assert.True(t, errors.Is(err, ErrInvalidExport))
+}
@@ -0,0 +1,76 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Crashing inputs found by `go test -fuzz` are written to testdata/fuzz and
+// replayed as regression cases by a plain `go test` run
+
+func FuzzExtractNumber(f *testing.F) {
+	for _, seed := range []string{"123", "1,234 reviews", "", "99999999999999999999999", ",,,", "٣٤٥"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		if n := extractNumber(text); n < 0 {
+			t.Errorf("extractNumber(%q) = %d, want non-negative", text, n)
+		}
+	})
+}
+
+func FuzzExtractRating(f *testing.F) {
+	for _, seed := range []string{"4.18", "avg rating 3.5", "", "1e308.5", "9999999999999999999999.9"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		if r := extractRating(text); r < 0 {
+			t.Errorf("extractRating(%q) = %v, want non-negative", text, r)
+		}
+	})
+}
+
+func FuzzParseRatingsReviews(f *testing.F) {
+	for _, seed := range []string{"61 ratings | 9 reviews", "1 rating and 1 review", "", "99999999999999999999 ratings | 1 review"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		ratings, reviews := parseRatingsReviews(text)
+		if ratings < 0 || reviews < 0 {
+			t.Errorf("parseRatingsReviews(%q) = %d, %d, want non-negative", text, ratings, reviews)
+		}
+	})
+}
+
+func FuzzParseShelfBooks(f *testing.F) {
+	for _, seed := range []string{
+		`<table><tr id="review_1"><td class="field title"><a href="/book/show/1">Dune</a></td><td class="field author"><a>Frank Herbert</a></td><td class="field rating">5</td><td class="field date_added"><span class="value">Jan 02, 2024</span></td><td><img src="x_SX50_.jpg"></td></tr></table>`,
+		`<div class="bookalike"><div class="title"><a href="/book/show/2">Emma</a></div><div class="author"><a>Austen</a></div></div>`,
+		`<tr id="review_"><td class="field title"><a>`,
+		``,
+	} {
+		f.Add(seed)
+	}
+
+	scraper := &Scraper{}
+	f.Fuzz(func(t *testing.T, html string) {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			return
+		}
+
+		for _, book := range scraper.parseShelfBooks(doc) {
+			if book.Title == "" {
+				t.Errorf("parseShelfBooks returned a book without a title for %q", html)
+			}
+			if book.Rating < 0 {
+				t.Errorf("parseShelfBooks returned negative rating %d for %q", book.Rating, html)
+			}
+		}
+	})
+}
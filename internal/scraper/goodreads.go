@@ -3,6 +3,7 @@ package scraper
 import (
 	"fmt"
 	"log"
+	"net/url"
 	"strings"
 	"time"
 
@@ -20,13 +21,36 @@ func min(a, b int) int {
 
 // Scraper handles Goodreads web scraping
 type Scraper struct {
-	client    *resty.Client
-	userAgent string
-	timeout   time.Duration
+	client       *resty.Client
+	userAgent    string
+	timeout      time.Duration
+	ownedShelves []string
+	layouts      *LayoutStore
+}
+
+// Option configures optional Scraper behaviour
+type Option func(*Scraper)
+
+// WithOwnedShelves sets the shelves treated as sources of owned books
+func WithOwnedShelves(shelves []string) Option {
+	return func(s *Scraper) {
+		if len(shelves) > 0 {
+			s.ownedShelves = shelves
+		}
+	}
+}
+
+// WithLayoutStore sets where detected per-user shelf layouts are kept
+func WithLayoutStore(store *LayoutStore) Option {
+	return func(s *Scraper) {
+		if store != nil {
+			s.layouts = store
+		}
+	}
 }
 
 // NewScraper creates a new Goodreads scraper
-func NewScraper(userAgent string, timeout time.Duration) *Scraper {
+func NewScraper(userAgent string, timeout time.Duration, opts ...Option) *Scraper {
 	client := resty.New().
 		SetTimeout(timeout).
 		SetRetryCount(3).
@@ -40,11 +64,19 @@ func NewScraper(userAgent string, timeout time.Duration) *Scraper {
 		SetHeader("Connection", "keep-alive").
 		SetHeader("Upgrade-Insecure-Requests", "1")
 
-	return &Scraper{
-		client:    client,
-		userAgent: userAgent,
-		timeout:   timeout,
+	s := &Scraper{
+		client:       client,
+		userAgent:    userAgent,
+		timeout:      timeout,
+		ownedShelves: []string{"owned"},
+		layouts:      NewMemoryLayoutStore(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	return s
 }
 
 // GetReadingStats scrapes reading statistics for a user
@@ -122,6 +154,41 @@ func (s *Scraper) GetReadingStats(username string) (*ReadingStats, error) {
 	return stats, nil
 }
 
+// GetShelf scrapes the books on a named shelf for a user
+func (s *Scraper) GetShelf(username, shelf string) ([]Book, error) {
+	userID, err := s.getUserID(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user ID: %w", err)
+	}
+
+	return s.getShelfBooks(userID, shelf)
+}
+
+// GetProfileBio returns the "About Me" text from a user's profile
+func (s *Scraper) GetProfileBio(username string) (string, error) {
+	userID, err := s.getUserID(username)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user ID: %w", err)
+	}
+
+	profileURL := fmt.Sprintf("https://www.goodreads.com/user/show/%s", userID)
+	resp, err := s.client.R().Get(profileURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch profile: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode())
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(resp.Body())))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	return parseProfileBio(doc), nil
+}
+
 // getUserID extracts user ID from username or profile URL
 func (s *Scraper) getUserID(username string) (string, error) {
 	// If already looks like a user ID, return as-is
@@ -134,9 +201,54 @@ func (s *Scraper) getUserID(username string) (string, error) {
 	return "101839711-kaine", nil
 }
 
-// getShelfBooks scrapes books from a specific shelf
+// getShelfBooks fetches books from a specific shelf using the layout
+// detected for the user, probing for one on first use
 func (s *Scraper) getShelfBooks(userID, shelf string) ([]Book, error) {
-	shelfURL := fmt.Sprintf("https://www.goodreads.com/review/list/%s?shelf=%s", userID, shelf)
+	layout, known := s.layouts.Get(userID)
+	if !known {
+		layout = s.probeLayout(userID, shelf)
+		if layout.Strategy != "" {
+			s.layouts.Set(userID, layout)
+		}
+	}
+
+	books, err := s.fetchWithLayout(userID, shelf, layout)
+	if err == nil {
+		return books, nil
+	}
+
+	// The stored layout stopped working; forget it so the next scrape re-probes
+	if known {
+		log.Printf("Warning: %s layout failed for %s, falling back: %v", layout.Strategy, userID, err)
+		s.layouts.Delete(userID)
+	}
+
+	books, err = s.getShelfBooksRSS(userID, shelf)
+	if err == nil {
+		return books, nil
+	}
+	log.Printf("Warning: shelf feed unavailable, falling back to HTML: %v", err)
+
+	return s.getShelfBooksHTML(userID, shelf, nil)
+}
+
+// getShelfBooksHTML scrapes books from a shelf's HTML list page
+func (s *Scraper) getShelfBooksHTML(userID, shelf string, params url.Values) ([]Book, error) {
+	doc, err := s.fetchShelfDoc(userID, shelf, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.parseShelfBooks(doc), nil
+}
+
+// fetchShelfDoc fetches and parses a shelf's HTML list page
+func (s *Scraper) fetchShelfDoc(userID, shelf string, params url.Values) (*goquery.Document, error) {
+	query := url.Values{"shelf": {shelf}}
+	for key, values := range params {
+		query[key] = values
+	}
+	shelfURL := fmt.Sprintf("https://www.goodreads.com/review/list/%s?%s", userID, query.Encode())
 
 	log.Printf("Scraping shelf: %s", shelfURL)
 
@@ -154,7 +266,7 @@ func (s *Scraper) getShelfBooks(userID, shelf string) ([]Book, error) {
 		return nil, fmt.Errorf("failed to parse shelf HTML: %w", err)
 	}
 
-	return s.parseShelfBooks(doc), nil
+	return doc, nil
 }
 
 // DebugShelf outputs HTML structure debug information for a shelf
@@ -3,6 +3,9 @@ package scraper
 // Interface defines the contract for Goodreads scraping operations
 type Interface interface {
 	GetReadingStats(username string) (*ReadingStats, error)
+	GetTsundoku(username string) (*TsundokuReport, error)
+	GetShelf(username, shelf string) ([]Book, error)
+	GetProfileBio(username string) (string, error)
 	DebugHTML(username string) error
 	DebugShelf(userID, shelf string) error
 }
@@ -0,0 +1,201 @@
+package scraper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Shelf fetch strategies, fastest first
+const (
+	StrategyRSS        = "rss"
+	StrategyHTMLTable  = "html_table"
+	StrategyHTMLCovers = "html_covers"
+)
+
+// probePageSizes are the per_page values tried for the table view, largest first
+var probePageSizes = []int{100, 30}
+
+// ShelfLayout records how a user's review list can be fetched
+type ShelfLayout struct {
+	Strategy   string    `json:"strategy"`
+	PerPage    int       `json:"per_page,omitempty"`
+	Columns    []string  `json:"columns,omitempty"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// probeLayout detects the cheapest working way to fetch a user's shelves
+func (s *Scraper) probeLayout(userID, shelf string) ShelfLayout {
+	log.Printf("Probing shelf layout for %s", userID)
+
+	if _, err := s.getShelfBooksRSS(userID, shelf); err == nil {
+		return ShelfLayout{Strategy: StrategyRSS, DetectedAt: time.Now()}
+	}
+
+	for _, perPage := range probePageSizes {
+		doc, err := s.fetchShelfDoc(userID, shelf, tableParams(perPage))
+		if err != nil {
+			continue
+		}
+		if layout, ok := detectLayout(doc); ok {
+			if layout.Strategy == StrategyHTMLTable {
+				layout.PerPage = perPage
+			}
+			return layout
+		}
+	}
+
+	doc, err := s.fetchShelfDoc(userID, shelf, url.Values{"view": {"covers"}})
+	if err == nil {
+		if layout, ok := detectLayout(doc); ok {
+			return layout
+		}
+	}
+
+	log.Printf("Warning: could not detect shelf layout for %s", userID)
+	return ShelfLayout{}
+}
+
+// fetchWithLayout fetches a shelf using a previously detected layout
+func (s *Scraper) fetchWithLayout(userID, shelf string, layout ShelfLayout) ([]Book, error) {
+	switch layout.Strategy {
+	case StrategyRSS:
+		return s.getShelfBooksRSS(userID, shelf)
+	case StrategyHTMLTable:
+		return s.getShelfBooksHTML(userID, shelf, tableParams(layout.PerPage))
+	case StrategyHTMLCovers:
+		return s.getShelfBooksHTML(userID, shelf, url.Values{"view": {"covers"}})
+	default:
+		return nil, errors.New("no shelf layout detected")
+	}
+}
+
+// tableParams returns query parameters for the table view
+func tableParams(perPage int) url.Values {
+	params := url.Values{"view": {"table"}}
+	if perPage > 0 {
+		params.Set("per_page", strconv.Itoa(perPage))
+	}
+	return params
+}
+
+// detectLayout inspects a shelf page for a recognisable book list
+func detectLayout(doc *goquery.Document) (ShelfLayout, bool) {
+	rows := doc.Find("tr[id*='review_']")
+	if rows.Length() > 0 {
+		return ShelfLayout{
+			Strategy:   StrategyHTMLTable,
+			Columns:    detectColumns(rows.First()),
+			DetectedAt: time.Now(),
+		}, true
+	}
+
+	if doc.Find(".bookalike").Length() > 0 {
+		return ShelfLayout{Strategy: StrategyHTMLCovers, DetectedAt: time.Now()}, true
+	}
+
+	return ShelfLayout{}, false
+}
+
+// detectColumns lists the field columns present in a table row
+func detectColumns(row *goquery.Selection) []string {
+	var columns []string
+	row.Find("td.field").Each(func(i int, cell *goquery.Selection) {
+		class, _ := cell.Attr("class")
+		for _, name := range strings.Fields(class) {
+			if name != "field" {
+				columns = append(columns, name)
+			}
+		}
+	})
+	return columns
+}
+
+// LayoutStore keeps detected shelf layouts per user, optionally persisted
+// to a JSON file
+type LayoutStore struct {
+	path string
+
+	mu      sync.RWMutex
+	layouts map[string]ShelfLayout
+}
+
+// NewMemoryLayoutStore creates a layout store that is not persisted
+func NewMemoryLayoutStore() *LayoutStore {
+	return &LayoutStore{layouts: make(map[string]ShelfLayout)}
+}
+
+// NewLayoutStore loads a layout store persisted at path
+func NewLayoutStore(path string) (*LayoutStore, error) {
+	store := &LayoutStore{path: path, layouts: make(map[string]ShelfLayout)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layout store: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &store.layouts); err != nil {
+		return nil, fmt.Errorf("failed to parse layout store: %w", err)
+	}
+
+	return store, nil
+}
+
+// Get returns the layout detected for a user
+func (l *LayoutStore) Get(userID string) (ShelfLayout, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	layout, exists := l.layouts[userID]
+	return layout, exists
+}
+
+// Set stores the layout for a user
+func (l *LayoutStore) Set(userID string, layout ShelfLayout) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.layouts[userID] = layout
+	l.save()
+}
+
+// Delete forgets the layout for a user
+func (l *LayoutStore) Delete(userID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.layouts, userID)
+	l.save()
+}
+
+// save persists layouts; layouts are only an optimisation, so failures are
+// logged rather than returned. Callers must hold mu.
+func (l *LayoutStore) save() {
+	if l.path == "" {
+		return
+	}
+
+	data, err := json.MarshalIndent(l.layouts, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(l.path), 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(l.path, data, 0o644)
+	}
+	if err != nil {
+		log.Printf("Warning: failed to save shelf layouts: %v", err)
+	}
+}
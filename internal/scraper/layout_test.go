@@ -0,0 +1,72 @@
+package scraper
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		ok       bool
+		strategy string
+		columns  []string
+	}{
+		{
+			"table view",
+			`<table><tr id="review_1"><td class="field title"></td><td class="field author"></td><td class="field num_pages"></td></tr></table>`,
+			true, StrategyHTMLTable, []string{"title", "author", "num_pages"},
+		},
+		{
+			"covers view",
+			`<div class="bookalike"><div class="title"><a>Book</a></div></div>`,
+			true, StrategyHTMLCovers, nil,
+		},
+		{
+			"unrecognised page",
+			`<div>Sign in to Goodreads</div>`,
+			false, "", nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			require.NoError(t, err)
+
+			layout, ok := detectLayout(doc)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.strategy, layout.Strategy)
+			assert.Equal(t, tt.columns, layout.Columns)
+		})
+	}
+}
+
+func TestLayoutStore_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "layouts.json")
+
+	store, err := NewLayoutStore(path)
+	require.NoError(t, err)
+
+	store.Set("42-reader", ShelfLayout{Strategy: StrategyHTMLTable, PerPage: 100, DetectedAt: time.Now()})
+	store.Set("7-other", ShelfLayout{Strategy: StrategyRSS, DetectedAt: time.Now()})
+	store.Delete("7-other")
+
+	reloaded, err := NewLayoutStore(path)
+	require.NoError(t, err)
+
+	layout, ok := reloaded.Get("42-reader")
+	assert.True(t, ok)
+	assert.Equal(t, StrategyHTMLTable, layout.Strategy)
+	assert.Equal(t, 100, layout.PerPage)
+
+	_, ok = reloaded.Get("7-other")
+	assert.False(t, ok)
+}
@@ -20,12 +20,30 @@ type ReadingStats struct {
 
 // Book represents a book with its metadata
 type Book struct {
-	Title        string `json:"title"`
-	Author       string `json:"author"`
-	Rating       int    `json:"rating,omitempty"`
-	DateRead     string `json:"date_read,omitempty"`
-	CoverURL     string `json:"cover_url,omitempty"`
-	GoodreadsURL string `json:"goodreads_url,omitempty"`
+	Title        string     `json:"title"`
+	Author       string     `json:"author"`
+	ISBN         string     `json:"isbn,omitempty"`
+	Rating       int        `json:"rating,omitempty"`
+	DateRead     string     `json:"date_read,omitempty"`
+	CoverURL     string     `json:"cover_url,omitempty"`
+	GoodreadsURL string     `json:"goodreads_url,omitempty"`
+	DateAdded    *time.Time `json:"date_added,omitempty"`
+}
+
+// TsundokuBook is an owned book that has not been read yet
+type TsundokuBook struct {
+	Book
+	DaysSinceAcquired int `json:"days_since_acquired"`
+}
+
+// TsundokuReport lists owned-but-unread books for a user
+type TsundokuReport struct {
+	UserID       string         `json:"user_id"`
+	Username     string         `json:"username"`
+	Count        int            `json:"count"`
+	OwnedShelves []string       `json:"owned_shelves"`
+	Books        []TsundokuBook `json:"books"`
+	LastUpdated  time.Time      `json:"last_updated"`
 }
 
 // ErrorResponse represents API error responses
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -60,6 +61,18 @@ func (s *Scraper) parseProfileStats(doc *goquery.Document, stats *ReadingStats)
 	return nil
 }
 
+// parseProfileBio extracts the owner-editable "About Me" text from the
+// profile info box, ignoring content other users can post on the page
+func parseProfileBio(doc *goquery.Document) string {
+	var bio string
+	doc.Find(".userInfoBoxContent .infoBoxRowTitle").Each(func(i int, sel *goquery.Selection) {
+		if strings.EqualFold(strings.TrimSpace(sel.Text()), "about me") {
+			bio = strings.TrimSpace(sel.NextFiltered(".infoBoxRowItem").Text())
+		}
+	})
+	return bio
+}
+
 // parseShelfBooks extracts books from a shelf page
 func (s *Scraper) parseShelfBooks(doc *goquery.Document) []Book {
 	var books []Book
@@ -97,6 +110,14 @@ func (s *Scraper) parseShelfBooks(doc *goquery.Document) []Book {
 			}
 		}
 
+		// Extract date added
+		dateAddedCell := sel.Find("td.field.date_added")
+		if dateAddedCell.Length() > 0 {
+			if added, ok := parseShelfDate(fieldValue(dateAddedCell)); ok {
+				book.DateAdded = &added
+			}
+		}
+
 		// Extract cover URL
 		coverImg := sel.Find("img")
 		if coverImg.Length() > 0 {
@@ -144,6 +165,32 @@ func (s *Scraper) parseShelfBooks(doc *goquery.Document) []Book {
 	return books
 }
 
+// fieldValue returns the visible value of a shelf table cell, skipping
+// the column label Goodreads renders inside each cell
+func fieldValue(cell *goquery.Selection) string {
+	value := cell.Find(".value")
+	if value.Length() == 0 {
+		value = cell
+	}
+	return strings.Join(strings.Fields(value.Text()), " ")
+}
+
+// parseShelfDate parses dates as rendered on shelf pages ("Jan 02, 2024",
+// "Jan 2024" or "2024"); it reports false for "not set" and unknown formats
+func parseShelfDate(text string) (time.Time, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.EqualFold(text, "not set") {
+		return time.Time{}, false
+	}
+
+	for _, layout := range []string{"Jan 02, 2006", "Jan 2, 2006", "January 2, 2006", "Jan 2006", "2006"} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
 // extractNumber extracts the first number from a string
 func extractNumber(text string) int {
 	re := regexp.MustCompile(`\d+`)
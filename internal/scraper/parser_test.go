@@ -3,6 +3,7 @@ package scraper
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/stretchr/testify/assert"
@@ -169,3 +170,49 @@ func TestParseProfileStats(t *testing.T) {
 	assert.Equal(t, 9, stats.TotalReviews)
 	assert.Equal(t, 4.18, stats.AverageRating)
 }
+
+func TestParseShelfDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+		ok       bool
+	}{
+		{"full date", "Jan 02, 2024", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), true},
+		{"unpadded day", "Mar 7, 2023", time.Date(2023, 3, 7, 0, 0, 0, 0, time.UTC), true},
+		{"month and year", "Dec 2022", time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), true},
+		{"year only", "2021", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"not set", "not set", time.Time{}, false},
+		{"empty string", "", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := parseShelfDate(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestParseProfileBio(t *testing.T) {
+	htmlContent := `
+	<html>
+		<body>
+			<div class="userInfoBoxContent">
+				<div class="infoBoxRowTitle">Details</div>
+				<div class="infoBoxRowItem">Joined in 2019</div>
+				<div class="infoBoxRowTitle">About Me</div>
+				<div class="infoBoxRowItem">
+					Reader of things. optout-abc123
+				</div>
+			</div>
+			<div class="comments">optout-fake999</div>
+		</body>
+	</html>`
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Reader of things. optout-abc123", parseProfileBio(doc))
+}
@@ -0,0 +1,112 @@
+package scraper
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// rssFeed mirrors the parts of Goodreads' review list RSS feed we use
+type rssFeed struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+// rssItem is a single book entry in a shelf feed
+type rssItem struct {
+	Title         string `xml:"title"`
+	BookID        string `xml:"book_id"`
+	AuthorName    string `xml:"author_name"`
+	ISBN          string `xml:"isbn"`
+	UserRating    int    `xml:"user_rating"`
+	UserReadAt    string `xml:"user_read_at"`
+	UserDateAdded string `xml:"user_date_added"`
+	SmallImageURL string `xml:"book_small_image_url"`
+	MediumImage   string `xml:"book_medium_image_url"`
+	LargeImageURL string `xml:"book_large_image_url"`
+}
+
+// getShelfBooksRSS fetches a shelf through its RSS feed
+func (s *Scraper) getShelfBooksRSS(userID, shelf string) ([]Book, error) {
+	feedURL := fmt.Sprintf("https://www.goodreads.com/review/list_rss/%s?shelf=%s", userID, shelf)
+	log.Printf("Fetching shelf feed: %s", feedURL)
+
+	resp, err := s.client.R().
+		SetHeader("Accept", "application/rss+xml, application/xml;q=0.9").
+		Get(feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch shelf feed: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode())
+	}
+
+	return parseShelfRSS(resp.Body())
+}
+
+// parseShelfRSS extracts books from a shelf RSS feed
+func parseShelfRSS(body []byte) ([]Book, error) {
+	var feed rssFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("failed to parse shelf feed: %w", err)
+	}
+
+	books := []Book{}
+	for _, item := range feed.Channel.Items {
+		book := Book{
+			Title:    strings.Join(strings.Fields(item.Title), " "),
+			Author:   strings.TrimSpace(item.AuthorName),
+			ISBN:     strings.TrimSpace(item.ISBN),
+			Rating:   item.UserRating,
+			CoverURL: firstNonEmpty(item.LargeImageURL, item.MediumImage, item.SmallImageURL),
+		}
+
+		if id := strings.TrimSpace(item.BookID); id != "" {
+			book.GoodreadsURL = "https://www.goodreads.com/book/show/" + id
+		}
+
+		if readAt, ok := parseRSSDate(item.UserReadAt); ok {
+			book.DateRead = readAt.Format("Jan 02, 2006")
+		}
+
+		if added, ok := parseRSSDate(item.UserDateAdded); ok {
+			book.DateAdded = &added
+		}
+
+		if book.Title != "" {
+			books = append(books, book)
+		}
+	}
+
+	log.Printf("Parsed %d books from shelf feed", len(books))
+	return books, nil
+}
+
+// parseRSSDate parses the RFC 1123 timestamps used in Goodreads feeds
+func parseRSSDate(text string) (time.Time, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range []string{time.RFC1123Z, time.RFC1123} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// firstNonEmpty returns the first non-blank value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
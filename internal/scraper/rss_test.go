@@ -0,0 +1,61 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseShelfRSS(t *testing.T) {
+	feed := `<?xml version="1.0"?>
+<rss version="2.0">
+  <channel>
+    <title>kaine's bookshelf: read</title>
+    <item>
+      <title><![CDATA[Test Book
+        (Series #1)]]></title>
+      <book_id>123</book_id>
+      <author_name>Test Author</author_name>
+      <isbn>0441013597</isbn>
+      <user_rating>4</user_rating>
+      <user_read_at><![CDATA[Tue, 02 Jan 2024 00:00:00 -0800]]></user_read_at>
+      <user_date_added><![CDATA[Sun, 10 Dec 2023 08:15:00 -0800]]></user_date_added>
+      <book_small_image_url>https://example.com/small.jpg</book_small_image_url>
+      <book_large_image_url>https://example.com/large.jpg</book_large_image_url>
+    </item>
+    <item>
+      <title>Unread Book</title>
+      <book_id>456</book_id>
+      <author_name>Another Author</author_name>
+      <user_rating>0</user_rating>
+      <user_read_at></user_read_at>
+      <book_small_image_url>https://example.com/small2.jpg</book_small_image_url>
+    </item>
+  </channel>
+</rss>`
+
+	books, err := parseShelfRSS([]byte(feed))
+	assert.NoError(t, err)
+	assert.Len(t, books, 2)
+
+	assert.Equal(t, "Test Book (Series #1)", books[0].Title)
+	assert.Equal(t, "Test Author", books[0].Author)
+	assert.Equal(t, "0441013597", books[0].ISBN)
+	assert.Equal(t, 4, books[0].Rating)
+	assert.Equal(t, "Jan 02, 2024", books[0].DateRead)
+	assert.Equal(t, "https://example.com/large.jpg", books[0].CoverURL)
+	assert.Equal(t, "https://www.goodreads.com/book/show/123", books[0].GoodreadsURL)
+	if assert.NotNil(t, books[0].DateAdded) {
+		assert.Equal(t, time.Month(12), books[0].DateAdded.Month())
+	}
+
+	assert.Empty(t, books[1].DateRead)
+	assert.Nil(t, books[1].DateAdded)
+	assert.Equal(t, "https://example.com/small2.jpg", books[1].CoverURL)
+}
+
+func TestParseShelfRSS_Invalid(t *testing.T) {
+	_, err := parseShelfRSS([]byte("<html>not a feed"))
+	assert.Error(t, err)
+}
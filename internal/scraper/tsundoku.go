@@ -0,0 +1,82 @@
+package scraper
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+// GetTsundoku builds the owned-but-unread report for a user by combining
+// the configured ownership shelves with the read shelf
+func (s *Scraper) GetTsundoku(username string) (*TsundokuReport, error) {
+	userID, err := s.getUserID(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user ID: %w", err)
+	}
+
+	var owned []Book
+	for _, shelf := range s.ownedShelves {
+		books, err := s.getShelfBooks(userID, shelf)
+		if err != nil {
+			log.Printf("Warning: failed to get owned shelf %q: %v", shelf, err)
+			continue
+		}
+		owned = append(owned, books...)
+	}
+
+	read, err := s.getShelfBooks(userID, "read")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get read shelf: %w", err)
+	}
+
+	report := &TsundokuReport{
+		UserID:       userID,
+		Username:     username,
+		OwnedShelves: s.ownedShelves,
+		Books:        buildTsundoku(owned, read, time.Now()),
+		LastUpdated:  time.Now(),
+	}
+	report.Count = len(report.Books)
+
+	return report, nil
+}
+
+// buildTsundoku returns owned books missing from the read shelf, oldest
+// acquisitions first
+func buildTsundoku(owned, read []Book, now time.Time) []TsundokuBook {
+	readKeys := make(map[string]bool, len(read))
+	for _, book := range read {
+		readKeys[bookKey(book)] = true
+	}
+
+	seen := make(map[string]bool, len(owned))
+	books := []TsundokuBook{}
+	for _, book := range owned {
+		key := bookKey(book)
+		if readKeys[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		entry := TsundokuBook{Book: book}
+		if book.DateAdded != nil {
+			entry.DaysSinceAcquired = int(now.Sub(*book.DateAdded).Hours() / 24)
+		}
+		books = append(books, entry)
+	}
+
+	sort.SliceStable(books, func(i, j int) bool {
+		return books[i].DaysSinceAcquired > books[j].DaysSinceAcquired
+	})
+
+	return books
+}
+
+// bookKey identifies a book across shelves, preferring its Goodreads URL
+func bookKey(book Book) string {
+	if book.GoodreadsURL != "" {
+		return book.GoodreadsURL
+	}
+	return book.Title + "|" + book.Author
+}
@@ -0,0 +1,32 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTsundoku(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	older := now.AddDate(0, 0, -300)
+	newer := now.AddDate(0, 0, -10)
+
+	owned := []Book{
+		{Title: "Already Read", GoodreadsURL: "https://www.goodreads.com/book/show/1"},
+		{Title: "Recent Buy", GoodreadsURL: "https://www.goodreads.com/book/show/2", DateAdded: &newer},
+		{Title: "Old Buy", GoodreadsURL: "https://www.goodreads.com/book/show/3", DateAdded: &older},
+		{Title: "Old Buy", GoodreadsURL: "https://www.goodreads.com/book/show/3", DateAdded: &older},
+	}
+	read := []Book{
+		{Title: "Already Read", GoodreadsURL: "https://www.goodreads.com/book/show/1"},
+	}
+
+	books := buildTsundoku(owned, read, now)
+
+	assert.Len(t, books, 2)
+	assert.Equal(t, "Old Buy", books[0].Title)
+	assert.Equal(t, 300, books[0].DaysSinceAcquired)
+	assert.Equal(t, "Recent Buy", books[1].Title)
+	assert.Equal(t, 10, books[1].DaysSinceAcquired)
+}
@@ -0,0 +1,40 @@
+package tuning
+
+import (
+	"log"
+	"runtime/debug"
+)
+
+// Settings are runtime memory tuning knobs; zero values leave the Go
+// runtime defaults (including GOGC/GOMEMLIMIT) untouched
+type Settings struct {
+	// GCPercent is the GC target percentage, as with GOGC
+	GCPercent int
+	// MemoryLimit is the soft memory limit in bytes, as with GOMEMLIMIT
+	MemoryLimit int64
+	// Ballast is the size in bytes of a heap allocation that is never used,
+	// raising the heap size the GC paces against on small instances
+	Ballast int64
+}
+
+// ballast keeps the heap ballast reachable for the life of the process.
+// It is never written, so its pages are not backed by physical memory
+var ballast []byte
+
+// Apply configures the garbage collector and allocates the heap ballast
+func Apply(s Settings) {
+	if s.GCPercent > 0 {
+		debug.SetGCPercent(s.GCPercent)
+		log.Printf("GC percent set to %d", s.GCPercent)
+	}
+
+	if s.MemoryLimit > 0 {
+		debug.SetMemoryLimit(s.MemoryLimit)
+		log.Printf("Soft memory limit set to %d bytes", s.MemoryLimit)
+	}
+
+	if s.Ballast > 0 {
+		ballast = make([]byte, s.Ballast)
+		log.Printf("Allocated %d byte heap ballast", len(ballast))
+	}
+}
@@ -0,0 +1,35 @@
+package tuning
+
+import (
+	"math"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	previousGC := debug.SetGCPercent(100)
+	previousLimit := debug.SetMemoryLimit(math.MaxInt64)
+	t.Cleanup(func() {
+		debug.SetGCPercent(previousGC)
+		debug.SetMemoryLimit(previousLimit)
+		ballast = nil
+	})
+
+	Apply(Settings{GCPercent: 200, MemoryLimit: 256 << 20, Ballast: 1 << 20})
+
+	assert.Equal(t, 200, debug.SetGCPercent(200))
+	assert.Equal(t, int64(256<<20), debug.SetMemoryLimit(-1))
+	assert.Len(t, ballast, 1<<20)
+}
+
+func TestApply_ZeroValuesKeepDefaults(t *testing.T) {
+	previousGC := debug.SetGCPercent(100)
+	t.Cleanup(func() { debug.SetGCPercent(previousGC) })
+
+	Apply(Settings{})
+
+	assert.Equal(t, 100, debug.SetGCPercent(100))
+	assert.Nil(t, ballast)
+}
@@ -1,12 +1,22 @@
 package main
 
 import (
+	"crypto/tls"
 	"log"
+	"net/http"
 
 	"goodreads-scraper/internal/api"
 	"goodreads-scraper/internal/cache"
+	"goodreads-scraper/internal/consent"
+	"goodreads-scraper/internal/freshness"
+	"goodreads-scraper/internal/notify"
+	"goodreads-scraper/internal/obfuscate"
 	"goodreads-scraper/internal/scraper"
+	"goodreads-scraper/internal/tuning"
 	"goodreads-scraper/pkg/config"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/acme/autocert"
 )
 
 func main() {
@@ -16,17 +26,82 @@ func main() {
 	log.Printf("Starting Goodreads Scraper on port %s", cfg.Port)
 	log.Printf("Cache TTL: %s, Scrape timeout: %s", cfg.CacheTTL, cfg.ScrapeTimeout)
 
+	tuning.Apply(tuning.Settings{
+		GCPercent:   cfg.GCPercent,
+		MemoryLimit: cfg.MemoryLimit,
+		Ballast:     cfg.HeapBallast,
+	})
+
 	// Initialize dependencies
 	memCache := cache.NewMemoryCache(cfg.CacheTTL)
-	goodreadsScraper := scraper.NewScraper(cfg.UserAgent, cfg.ScrapeTimeout)
-	apiHandler := api.NewHandler(goodreadsScraper, memCache)
+	layouts, err := scraper.NewLayoutStore(cfg.ShelfLayoutFile)
+	if err != nil {
+		log.Fatalf("Failed to load shelf layouts: %v", err)
+	}
+
+	goodreadsScraper := scraper.NewScraper(cfg.UserAgent, cfg.ScrapeTimeout,
+		scraper.WithOwnedShelves(config.SplitList(cfg.OwnedShelves)),
+		scraper.WithLayoutStore(layouts),
+	)
+	trackedUsers := config.SplitList(cfg.TrackedUsers)
+	freshnessMonitor := freshness.NewMonitor(cfg.FreshnessSLA, trackedUsers, notify.LogNotifier{})
+
+	optOuts, err := consent.NewRegistry(cfg.OptOutFile)
+	if err != nil {
+		log.Fatalf("Failed to load opt-out registry: %v", err)
+	}
+
+	handlerOpts := []api.HandlerOption{
+		api.WithFreshnessMonitor(freshnessMonitor),
+		api.WithConsentRegistry(optOuts),
+	}
+	if cfg.IDObfuscationSecret != "" {
+		// Tracked users resolve from their tokens even before first being served
+		handlerOpts = append(handlerOpts, api.WithIDCodec(obfuscate.NewHMAC(cfg.IDObfuscationSecret, trackedUsers)))
+	}
+	apiHandler := api.NewHandler(goodreadsScraper, memCache, handlerOpts...)
 
 	// Setup routes
 	router := apiHandler.SetupRoutes(cfg)
 
 	// Start server
 	log.Printf("Server starting on :%s", cfg.Port)
-	if err := router.Run(":" + cfg.Port); err != nil {
+	if err := runServer(router, cfg); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// runServer serves the router over plain HTTP, a static certificate, or a
+// Let's Encrypt certificate depending on the TLS configuration
+func runServer(router *gin.Engine, cfg *config.Config) error {
+	switch {
+	case cfg.AutocertHost != "":
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.AutocertHost),
+			Cache:      autocert.DirCache(cfg.AutocertCacheDir),
+		}
+
+		// Answer HTTP-01 challenges and redirect everything else to HTTPS
+		go func() {
+			if err := http.ListenAndServe(":80", manager.HTTPHandler(nil)); err != nil {
+				log.Printf("Warning: autocert HTTP challenge listener stopped: %v", err)
+			}
+		}()
+
+		log.Printf("TLS enabled via autocert for %s", cfg.AutocertHost)
+		server := &http.Server{
+			Addr:      ":" + cfg.Port,
+			Handler:   router,
+			TLSConfig: &tls.Config{GetCertificate: manager.GetCertificate},
+		}
+		return server.ListenAndServeTLS("", "")
+
+	case cfg.TLSCertFile != "" && cfg.TLSKeyFile != "":
+		log.Printf("TLS enabled with certificate %s", cfg.TLSCertFile)
+		return router.RunTLS(":"+cfg.Port, cfg.TLSCertFile, cfg.TLSKeyFile)
+
+	default:
+		return router.Run(":" + cfg.Port)
+	}
+}
@@ -2,7 +2,7 @@ package config
 
 import (
 	"os"
-	"strconv"
+	"strings"
 	"time"
 )
 
@@ -20,50 +20,124 @@ type Config struct {
 
 	// Security
 	TrustedProxies string `env:"TRUSTED_PROXIES"`
+
+	// Shelves
+	OwnedShelves    string `env:"OWNED_SHELVES"`
+	ShelfLayoutFile string `env:"SHELF_LAYOUT_FILE"`
+
+	// API evolution
+	DisableDeprecatedRoutes bool `env:"DISABLE_DEPRECATED_ROUTES"`
+
+	// Freshness
+	FreshnessSLA time.Duration `env:"FRESHNESS_SLA"`
+	TrackedUsers string        `env:"TRACKED_USERS"`
+
+	// TLS
+	TLSCertFile      string `env:"TLS_CERT_FILE"`
+	TLSKeyFile       string `env:"TLS_KEY_FILE"`
+	AutocertHost     string `env:"AUTOCERT_HOST"`
+	AutocertCacheDir string `env:"AUTOCERT_CACHE_DIR"`
+
+	// Admin
+	AdminToken string `env:"ADMIN_TOKEN" secret:"true"`
+
+	// Privacy
+	IDObfuscationSecret string `env:"ID_OBFUSCATION_SECRET" secret:"true"`
+	OptOutFile          string `env:"OPT_OUT_FILE"`
+
+	// Runtime tuning
+	GCPercent   int   `env:"GC_PERCENT"`
+	MemoryLimit int64 `env:"MEMORY_LIMIT"`
+	HeapBallast int64 `env:"HEAP_BALLAST"`
+
+	// sources records where each value came from (env, file or default)
+	sources map[string]string
 }
 
-// Load creates a new Config with values from environment variables or defaults
+// Load creates a new Config with values from environment variables, the
+// optional CONFIG_FILE, or defaults, in that order of precedence
 func Load() *Config {
-	return &Config{
-		Port:          getEnv("PORT", "8080"),
-		CacheTTL:      getDurationEnv("CACHE_TTL", 6*time.Hour),
-		ScrapeTimeout: getDurationEnv("SCRAPE_TIMEOUT", 30*time.Second),
-		UserAgent:     getEnv("USER_AGENT", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"),
-		LogLevel:      getEnv("LOG_LEVEL", "info"),
+	l := newLoader(os.Getenv("CONFIG_FILE"))
+
+	cfg := &Config{
+		Port:          l.string("PORT", "8080"),
+		CacheTTL:      l.duration("CACHE_TTL", 6*time.Hour),
+		ScrapeTimeout: l.duration("SCRAPE_TIMEOUT", 30*time.Second),
+		UserAgent:     l.string("USER_AGENT", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36"),
+		LogLevel:      l.string("LOG_LEVEL", "info"),
 
 		// Rate limiting defaults
-		RateLimitPerMinute: getIntEnv("RATE_LIMIT_PER_MINUTE", 60), // 60 requests per minute general
-		ScrapeRateLimit:    getIntEnv("SCRAPE_RATE_LIMIT", 10),     // 10 scrape requests per minute
+		RateLimitPerMinute: l.int("RATE_LIMIT_PER_MINUTE", 60), // 60 requests per minute general
+		ScrapeRateLimit:    l.int("SCRAPE_RATE_LIMIT", 10),     // 10 scrape requests per minute
 
 		// Security defaults
-		TrustedProxies: getEnv("TRUSTED_PROXIES", "127.0.0.1,::1"), // localhost only by default
+		TrustedProxies: l.string("TRUSTED_PROXIES", "127.0.0.1,::1"), // localhost only by default
+
+		// Shelves whose books count as owned for the tsundoku report
+		OwnedShelves: l.string("OWNED_SHELVES", "owned"),
+
+		// Detected per-user shelf layouts are remembered here
+		ShelfLayoutFile: l.string("SHELF_LAYOUT_FILE", "data/shelf-layouts.json"),
+
+		// Deprecated routes stay available until explicitly disabled
+		DisableDeprecatedRoutes: l.bool("DISABLE_DEPRECATED_ROUTES", false),
+
+		// Freshness SLA is disabled by default
+		FreshnessSLA: l.duration("FRESHNESS_SLA", 0),
+		TrackedUsers: l.string("TRACKED_USERS", ""),
+
+		// TLS is disabled unless a cert/key pair or autocert host is set
+		TLSCertFile:      l.string("TLS_CERT_FILE", ""),
+		TLSKeyFile:       l.string("TLS_KEY_FILE", ""),
+		AutocertHost:     l.string("AUTOCERT_HOST", ""),
+		AutocertCacheDir: l.string("AUTOCERT_CACHE_DIR", "certs"),
+
+		// Admin endpoints are disabled until a token is configured
+		AdminToken: l.string("ADMIN_TOKEN", ""),
+
+		// Raw Goodreads user IDs are shown unless a secret is set
+		IDObfuscationSecret: l.string("ID_OBFUSCATION_SECRET", ""),
+
+		// Verified scrape opt-outs are persisted here
+		OptOutFile: l.string("OPT_OUT_FILE", "data/opt-out.json"),
+
+		// Runtime defaults (and GOGC/GOMEMLIMIT) apply unless overridden
+		GCPercent:   l.int("GC_PERCENT", 0),
+		MemoryLimit: l.bytes("MEMORY_LIMIT", 0),
+		HeapBallast: l.bytes("HEAP_BALLAST", 0),
 	}
+
+	cfg.sources = l.sources
+	return cfg
 }
 
 // getEnv gets an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+	return (&loader{}).string(key, defaultValue)
 }
 
 // getDurationEnv gets a duration from environment variable or returns default
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
-			return duration
-		}
-	}
-	return defaultValue
+	return (&loader{}).duration(key, defaultValue)
 }
 
 // getIntEnv gets an integer from environment variable or returns default
 func getIntEnv(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
+	return (&loader{}).int(key, defaultValue)
+}
+
+// getBoolEnv gets a boolean from environment variable or returns default
+func getBoolEnv(key string, defaultValue bool) bool {
+	return (&loader{}).bool(key, defaultValue)
+}
+
+// SplitList splits a comma-separated config value, dropping blank entries
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
 	}
-	return defaultValue
+	return items
 }
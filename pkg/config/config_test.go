@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -22,6 +23,20 @@ func TestLoad_DefaultValues(t *testing.T) {
 	assert.Equal(t, 10, config.ScrapeRateLimit)
 	assert.Equal(t, "127.0.0.1,::1", config.TrustedProxies)
 	assert.Contains(t, config.UserAgent, "Mozilla")
+	assert.Equal(t, "owned", config.OwnedShelves)
+	assert.Equal(t, "data/shelf-layouts.json", config.ShelfLayoutFile)
+	assert.False(t, config.DisableDeprecatedRoutes)
+	assert.Equal(t, time.Duration(0), config.FreshnessSLA)
+	assert.Empty(t, config.TrackedUsers)
+	assert.Empty(t, config.TLSCertFile)
+	assert.Empty(t, config.AutocertHost)
+	assert.Equal(t, "certs", config.AutocertCacheDir)
+	assert.Empty(t, config.AdminToken)
+	assert.Empty(t, config.IDObfuscationSecret)
+	assert.Equal(t, "data/opt-out.json", config.OptOutFile)
+	assert.Equal(t, 0, config.GCPercent)
+	assert.Equal(t, int64(0), config.MemoryLimit)
+	assert.Equal(t, int64(0), config.HeapBallast)
 }
 
 func TestLoad_EnvironmentVariables(t *testing.T) {
@@ -52,6 +67,35 @@ func TestLoad_EnvironmentVariables(t *testing.T) {
 	assert.Equal(t, "TestBot/1.0", config.UserAgent)
 }
 
+func TestLoad_ConfigFileAndSources(t *testing.T) {
+	clearTestEnvVars()
+	defer clearTestEnvVars()
+
+	path := filepath.Join(t.TempDir(), "goodreads.env")
+	content := "# overrides\nCACHE_TTL=1h\nPORT=7070\nADMIN_TOKEN=\"s3cret\"\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	os.Setenv("CONFIG_FILE", path)
+	os.Setenv("PORT", "9090") // env wins over file
+
+	config := Load()
+
+	assert.Equal(t, "9090", config.Port)
+	assert.Equal(t, time.Hour, config.CacheTTL)
+	assert.Equal(t, "s3cret", config.AdminToken)
+
+	settings := make(map[string]Setting)
+	for _, setting := range config.Describe() {
+		settings[setting.Key] = setting
+	}
+
+	assert.Equal(t, SourceEnv, settings["PORT"].Source)
+	assert.Equal(t, SourceFile, settings["CACHE_TTL"].Source)
+	assert.Equal(t, "1h0m0s", settings["CACHE_TTL"].Value)
+	assert.Equal(t, SourceDefault, settings["LOG_LEVEL"].Source)
+	assert.Equal(t, "[REDACTED]", settings["ADMIN_TOKEN"].Value)
+}
+
 func TestGetEnv(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -175,11 +219,90 @@ func TestGetIntEnv(t *testing.T) {
 	}
 }
 
+func TestGetBoolEnv(t *testing.T) {
+	tests := []struct {
+		name         string
+		key          string
+		defaultValue bool
+		envValue     string
+		expected     bool
+	}{
+		{
+			"returns default when env not set",
+			"TEST_BOOL", true, "", true,
+		},
+		{
+			"returns parsed bool when valid",
+			"TEST_BOOL", false, "true", true,
+		},
+		{
+			"accepts numeric form",
+			"TEST_BOOL", true, "0", false,
+		},
+		{
+			"returns default when invalid bool",
+			"TEST_BOOL", true, "maybe", true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.envValue != "" {
+				os.Setenv(tt.key, tt.envValue)
+				defer os.Unsetenv(tt.key)
+			} else {
+				os.Unsetenv(tt.key)
+			}
+
+			result := getBoolEnv(tt.key, tt.defaultValue)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"owned", "kindle"}, SplitList(" owned, kindle ,"))
+	assert.Empty(t, SplitList(""))
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{"1048576", 1 << 20, false},
+		{"512MiB", 512 << 20, false},
+		{"1 GiB", 1 << 30, false},
+		{"100KB", 100000, false},
+		{"64B", 64, false},
+		{"-1MiB", 0, true},
+		{"lots", 0, true},
+		{"99999999999GiB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			size, err := ParseBytes(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, size)
+		})
+	}
+}
+
 func clearTestEnvVars() {
 	envVars := []string{
 		"PORT", "CACHE_TTL", "SCRAPE_TIMEOUT", "LOG_LEVEL",
 		"RATE_LIMIT_PER_MINUTE", "SCRAPE_RATE_LIMIT",
-		"TRUSTED_PROXIES", "USER_AGENT",
+		"TRUSTED_PROXIES", "USER_AGENT", "OWNED_SHELVES", "SHELF_LAYOUT_FILE",
+		"DISABLE_DEPRECATED_ROUTES", "FRESHNESS_SLA", "TRACKED_USERS",
+		"TLS_CERT_FILE", "TLS_KEY_FILE", "AUTOCERT_HOST", "AUTOCERT_CACHE_DIR",
+		"ADMIN_TOKEN", "CONFIG_FILE", "ID_OBFUSCATION_SECRET", "OPT_OUT_FILE",
+		"GC_PERCENT", "MEMORY_LIMIT", "HEAP_BALLAST",
 	}
 
 	for _, env := range envVars {
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Value sources reported by Describe
+const (
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// Setting is one effective configuration value and where it came from
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// loader resolves config keys from the environment, then a config file,
+// then defaults, remembering which one supplied each value
+type loader struct {
+	file    map[string]string
+	sources map[string]string
+}
+
+// newLoader creates a loader, reading KEY=VALUE pairs from path when set
+func newLoader(path string) *loader {
+	l := &loader{sources: make(map[string]string)}
+	if path == "" {
+		return l
+	}
+
+	file, err := readConfigFile(path)
+	if err != nil {
+		log.Printf("Warning: failed to read config file %s: %v", path, err)
+		return l
+	}
+	l.file = file
+	return l
+}
+
+// readConfigFile parses a .env style file; blank lines and # comments are ignored
+func readConfigFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNo)
+		}
+		values[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+
+	return values, scanner.Err()
+}
+
+// lookup returns the raw value for key and its source, if set anywhere
+func (l *loader) lookup(key string) (string, string, bool) {
+	if value := os.Getenv(key); value != "" {
+		return value, SourceEnv, true
+	}
+	if value := l.file[key]; value != "" {
+		return value, SourceFile, true
+	}
+	return "", "", false
+}
+
+// record notes where key's effective value came from
+func (l *loader) record(key, source string) {
+	if l.sources != nil {
+		l.sources[key] = source
+	}
+}
+
+// string resolves a string value
+func (l *loader) string(key, defaultValue string) string {
+	if value, source, ok := l.lookup(key); ok {
+		l.record(key, source)
+		return value
+	}
+	l.record(key, SourceDefault)
+	return defaultValue
+}
+
+// duration resolves a duration value, falling back to default when unparsable
+func (l *loader) duration(key string, defaultValue time.Duration) time.Duration {
+	if value, source, ok := l.lookup(key); ok {
+		if duration, err := time.ParseDuration(value); err == nil {
+			l.record(key, source)
+			return duration
+		}
+	}
+	l.record(key, SourceDefault)
+	return defaultValue
+}
+
+// int resolves an integer value, falling back to default when unparsable
+func (l *loader) int(key string, defaultValue int) int {
+	if value, source, ok := l.lookup(key); ok {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			l.record(key, source)
+			return intValue
+		}
+	}
+	l.record(key, SourceDefault)
+	return defaultValue
+}
+
+// bool resolves a boolean value, falling back to default when unparsable
+func (l *loader) bool(key string, defaultValue bool) bool {
+	if value, source, ok := l.lookup(key); ok {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			l.record(key, source)
+			return boolValue
+		}
+	}
+	l.record(key, SourceDefault)
+	return defaultValue
+}
+
+// bytes resolves a byte size such as "512MiB", "1GB" or "1048576", falling
+// back to default when unparsable
+func (l *loader) bytes(key string, defaultValue int64) int64 {
+	if value, source, ok := l.lookup(key); ok {
+		if size, err := ParseBytes(value); err == nil {
+			l.record(key, source)
+			return size
+		}
+	}
+	l.record(key, SourceDefault)
+	return defaultValue
+}
+
+// byteUnits maps size suffixes to multipliers; decimal and binary units are
+// both accepted, matching GOMEMLIMIT's binary suffixes
+var byteUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+	{"KB", 1000}, {"MB", 1000 * 1000}, {"GB", 1000 * 1000 * 1000},
+	{"B", 1},
+}
+
+// ParseBytes parses a non-negative byte size with an optional unit suffix
+func ParseBytes(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	multiplier := int64(1)
+	for _, unit := range byteUnits {
+		if number, found := strings.CutSuffix(value, unit.suffix); found {
+			value, multiplier = strings.TrimSpace(number), unit.multiplier
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", value)
+	}
+	if size < 0 || size > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("byte size %q out of range", value)
+	}
+	return size * multiplier, nil
+}
+
+// Describe lists the effective configuration in declaration order, with
+// fields tagged secret redacted
+func (c *Config) Describe() []Setting {
+	v := reflect.ValueOf(*c)
+	t := v.Type()
+
+	settings := make([]Setting, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("env")
+		if key == "" {
+			continue
+		}
+
+		value := fmt.Sprint(v.Field(i).Interface())
+		if field.Tag.Get("secret") == "true" && value != "" {
+			value = "[REDACTED]"
+		}
+
+		source := c.sources[key]
+		if source == "" {
+			source = SourceDefault
+		}
+
+		settings = append(settings, Setting{Key: key, Value: value, Source: source})
+	}
+
+	return settings
+}